@@ -6,18 +6,47 @@ import (
 	"github.com/go-playground/validator/v10"
 	goredis "github.com/go-redis/redis/v9"
 	"github.com/gorilla/mux"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/handlers/activity"
+	activityNative "github.com/hardiksachan/kanban_board/backend/internal/activity/repository/native"
+	activityPostgres "github.com/hardiksachan/kanban_board/backend/internal/activity/repository/postgres"
+	activityDao "github.com/hardiksachan/kanban_board/backend/internal/activity/repository/postgres/activity/dao"
+	boardDomain "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	boardPorts "github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/board"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/card"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/column"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/events"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/member"
+	boardNative "github.com/hardiksachan/kanban_board/backend/internal/boards/repository/native"
+	boardPostgres "github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres"
+	boardDao "github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres/board/dao"
+	boardRedis "github.com/hardiksachan/kanban_board/backend/internal/boards/repository/redis"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/account"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/email"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/mfa"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/oauth"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/password"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/session"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/token"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/user"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/verification"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/repository"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/native"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/postgres"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/postgres/user/dao"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/redis"
 	"github.com/hardiksachan/kanban_board/backend/shared/logging"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"golang.org/x/crypto/bcrypt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -43,6 +72,129 @@ func main() {
 	jwtKey := os.Getenv("JWT_SIGNING_KEY")
 	logger.Debug(fmt.Sprintf("jwt key in env: %s", jwtKey))
 
+	jwtIssuer := os.Getenv("JWT_ISSUER")
+	if jwtIssuer == "" {
+		jwtIssuer = "kanban-board"
+	}
+	jwtAudience := os.Getenv("JWT_AUDIENCE")
+	if jwtAudience == "" {
+		jwtAudience = "kanban-board"
+	}
+	logger.Debug(fmt.Sprintf("jwt issuer in env: %s, audience: %s", jwtIssuer, jwtAudience))
+
+	// servers verifying tokens may be up to 30 seconds ahead or behind unless configured otherwise
+	jwtClockSkew := time.Second * 30
+	if clockSkew := os.Getenv("JWT_CLOCK_SKEW"); clockSkew != "" {
+		parsed, err := time.ParseDuration(clockSkew)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't parse JWT_CLOCK_SKEW. %s", err.Error()))
+			os.Exit(1)
+		}
+		jwtClockSkew = parsed
+	}
+	logger.Debug(fmt.Sprintf("jwt clock skew in env: %s", jwtClockSkew))
+
+	// access tokens are signed with the keys in JWT_KEYS_DIR, which other services can verify
+	// through the JWKS endpoint. Without it they fall back to the shared JWT_SIGNING_KEY.
+	accessKeys := repository.NewHMACKeySet(jwtKey)
+	if jwtKeysDir := os.Getenv("JWT_KEYS_DIR"); jwtKeysDir != "" {
+		signingKeyID := os.Getenv("JWT_SIGNING_KEY_ID")
+		logger.Debug(fmt.Sprintf("jwt keys in env: %s, signing with: %s", jwtKeysDir, signingKeyID))
+
+		keySet, err := repository.LoadKeySet(jwtKeysDir, signingKeyID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't load jwt keys. %s", err.Error()))
+			os.Exit(1)
+		}
+		accessKeys = keySet
+	}
+
+	appUrl := os.Getenv("APP_URL")
+	logger.Debug(fmt.Sprintf("App URL in env: %s", appUrl))
+
+	verifyEmailForLogin := os.Getenv("VERIFY_EMAIL_FOR_LOGIN") == "true"
+
+	// verification links are signed with their own secret, JWT_SIGNING_KEY is optional once
+	// JWT_KEYS_DIR is set
+	emailVerificationSecret := os.Getenv("EMAIL_VERIFICATION_SECRET")
+	if emailVerificationSecret == "" {
+		logger.Error("environment variable EMAIL_VERIFICATION_SECRET not set")
+		os.Exit(1)
+	}
+	logger.Debug(fmt.Sprintf("Verify email for login in env: %t", verifyEmailForLogin))
+
+	verifyEmailForInvites := os.Getenv("VERIFY_EMAIL_FOR_INVITES") == "true"
+	logger.Debug(fmt.Sprintf("Verify email for invites in env: %t", verifyEmailForInvites))
+
+	// deleted accounts can be restored for 30 days unless configured otherwise
+	deletionGracePeriod := time.Hour * 24 * 30
+	if gracePeriod := os.Getenv("ACCOUNT_DELETION_GRACE_PERIOD"); gracePeriod != "" {
+		parsed, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't parse ACCOUNT_DELETION_GRACE_PERIOD. %s", err.Error()))
+			os.Exit(1)
+		}
+		deletionGracePeriod = parsed
+	}
+	logger.Debug(fmt.Sprintf("Account deletion grace period in env: %s", deletionGracePeriod))
+
+	// passwords need 8 characters mixing 2 kinds of characters unless configured otherwise
+	passwordMinLength, passwordMinClasses := 8, 2
+	if minLength := os.Getenv("PASSWORD_MIN_LENGTH"); minLength != "" {
+		parsed, err := strconv.Atoi(minLength)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't parse PASSWORD_MIN_LENGTH. %s", err.Error()))
+			os.Exit(1)
+		}
+		passwordMinLength = parsed
+	}
+	if minClasses := os.Getenv("PASSWORD_MIN_CLASSES"); minClasses != "" {
+		parsed, err := strconv.Atoi(minClasses)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't parse PASSWORD_MIN_CLASSES. %s", err.Error()))
+			os.Exit(1)
+		}
+		passwordMinClasses = parsed
+	}
+	logger.Debug(fmt.Sprintf("Password policy in env: min length %d, min classes %d", passwordMinLength, passwordMinClasses))
+
+	// passwords are only checked against a list of breached passwords if one is configured
+	var breachedPasswords *native.BreachedPasswords
+	if breachedFile := os.Getenv("BREACHED_PASSWORDS_FILE"); breachedFile != "" {
+		logger.Debug(fmt.Sprintf("Breached passwords file in env: %s", breachedFile))
+
+		loaded, err := native.LoadBreachedPasswords(breachedFile)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't load breached passwords. %s", err.Error()))
+			os.Exit(1)
+		}
+		breachedPasswords = loaded
+	}
+	passwordPolicy := native.NewPasswordPolicy(passwordMinLength, passwordMinClasses, breachedPasswords)
+
+	// new passwords are hashed with Argon2id unless configured otherwise. Changing the hasher
+	// rehashes passwords as users log in, hashes made before keep working until then.
+	var passwordHasher ports.PasswordHasher
+	switch hasher := os.Getenv("PASSWORD_HASHER"); hasher {
+	case "", "argon2id":
+		// the second recommended option of RFC 9106, for machines with less memory to spare
+		passwordHasher = native.NewArgon2idHasher(64*1024, 3, 4)
+	case "bcrypt":
+		bcryptCost := bcrypt.DefaultCost
+		if cost := os.Getenv("BCRYPT_COST"); cost != "" {
+			parsed, err := strconv.Atoi(cost)
+			if err != nil {
+				logger.Error(fmt.Sprintf("Can't parse BCRYPT_COST. %s", err.Error()))
+				os.Exit(1)
+			}
+			bcryptCost = parsed
+		}
+		passwordHasher = native.NewBcryptHasher(bcryptCost)
+	default:
+		logger.Error(fmt.Sprintf("Unknown PASSWORD_HASHER %s, use argon2id or bcrypt", hasher))
+		os.Exit(1)
+	}
+
 	pg, err := pgxpool.Connect(context.Background(), pgUrl)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Can't connect to database. %s", err.Error()))
@@ -50,15 +202,154 @@ func main() {
 	}
 
 	pgq := dao.New(pg)
+	boardPgq := boardDao.New(pg)
+	activityPgq := activityDao.New(pg)
+
+	activityService := activityPorts.NewActivityService(activityPostgres.NewActivityStore(activityPgq))
+	activityRecorder := activityNative.NewRecorder(activityService, logger)
+
+	redisClient := goredis.NewClient(&goredis.Options{
+		Addr:     rAddr,
+		Password: rPass,
+	})
+
+	credentialStore := postgres.NewCredentialStore(pg)
+
+	loginLimiter := redis.NewLoginLimiter(redisClient)
+
+	// access tokens live for 2 minutes, a denylist rejects the ones revoked before that
+	accessTokenExpiry := time.Minute * 2
+	accessDenylist := redis.NewAccessDenylist(redisClient, accessTokenExpiry, jwtClockSkew)
+
+	refreshStore := redis.NewRefreshTokenStore(redisClient, accessDenylist)
+
+	// todo: send mails for real, the log mailer only works for local development
+	mailer := native.NewLogMailer(logger)
+
+	verificationService := ports.NewVerificationService(
+		credentialStore,
+		repository.NewVerificationProvider(emailVerificationSecret, time.Hour*24),
+		mailer,
+		activityRecorder,
+		appUrl+"/verify-email",
+	)
+
+	mfaService := ports.NewMFAService(credentialStore, postgres.NewTOTPStore(pg), activityRecorder, "Kanban Board")
+
+	personalTokenService := ports.NewPersonalAccessTokenService(postgres.NewPersonalAccessTokenStore(pgq), activityRecorder)
+
+	authService := ports.NewAuthService(
+		credentialStore,
+		repository.NewAccessTokenStore(accessKeys, jwtIssuer, jwtAudience, accessTokenExpiry, jwtClockSkew),
+		accessDenylist,
+		refreshStore,
+		activityRecorder,
+		native.NewVerificationSender(verificationService, logger),
+		mfaService,
+		redis.NewChallengeStore(redisClient),
+		loginLimiter,
+		passwordPolicy,
+		passwordHasher,
+		personalTokenService,
+		verifyEmailForLogin,
+	)
 
 	authHandler := auth.NewAuthHandler(
-		ports.NewAuthService(
-			postgres.NewCredentialStore(pgq),
-			repository.NewAccessTokenStore(jwtKey, time.Minute*2),
-			redis.NewRefreshTokenStore(goredis.NewClient(&goredis.Options{
-				Addr:     rAddr,
-				Password: rPass,
-			})),
+		authService,
+		logger,
+		validator.New(),
+	)
+
+	sessionHandler := session.NewSessionHandler(authService, logger)
+
+	tokenHandler := token.NewTokenHandler(personalTokenService, logger, validator.New())
+
+	mfaHandler := mfa.NewMFAHandler(mfaService, logger, validator.New())
+
+	// logging in with a company identity provider is only offered when one is configured. The
+	// provider sends users back to the web app, which hands the code to the callback endpoint.
+	identityProviders := map[string]ports.IdentityProvider{}
+	if oidcIssuer := os.Getenv("OIDC_ISSUER"); oidcIssuer != "" {
+		oidcName := os.Getenv("OIDC_PROVIDER")
+		if oidcName == "" {
+			oidcName = "oidc"
+		}
+
+		identityProvider, err := repository.NewOIDCIdentityProvider(
+			oidcName,
+			oidcIssuer,
+			os.Getenv("OIDC_CLIENT_ID"),
+			os.Getenv("OIDC_CLIENT_SECRET"),
+			appUrl+"/oauth/"+oidcName+"/callback",
+		)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't set up identity provider. %s", err.Error()))
+			os.Exit(1)
+		}
+		identityProviders[oidcName] = identityProvider
+		logger.Debug(fmt.Sprintf("Identity provider %s in env: %s", oidcName, oidcIssuer))
+	}
+
+	oauthHandler := oauth.NewOAuthHandler(
+		ports.NewExternalAuthService(
+			authService,
+			identityProviders,
+			redis.NewOAuthStateStore(redisClient),
+			postgres.NewExternalIdentityStore(pgq),
+			credentialStore,
+			activityRecorder,
+		),
+		logger,
+		validator.New(),
+	)
+
+	accountService := ports.NewAccountService(credentialStore, refreshStore, accessDenylist, personalTokenService, loginLimiter, passwordHasher, activityRecorder, deletionGracePeriod)
+
+	accountHandler := account.NewAccountHandler(accountService, logger, validator.New())
+
+	// accounts past their grace period are purged hourly
+	go func() {
+		for range time.Tick(time.Hour) {
+			purged, err := accountService.Purge()
+			if err != nil {
+				logger.Warn(fmt.Sprintf("Can't purge deleted accounts. %s", err.Error()))
+			}
+			if purged > 0 {
+				logger.Debug(fmt.Sprintf("Purged %d deleted accounts", purged))
+			}
+		}
+	}()
+
+	verificationHandler := verification.NewVerificationHandler(verificationService, logger, validator.New())
+
+	emailHandler := email.NewEmailHandler(
+		ports.NewEmailService(
+			credentialStore,
+			redis.NewEmailChangeStore(redisClient),
+			refreshStore,
+			accessDenylist,
+			personalTokenService,
+			mailer,
+			activityRecorder,
+			appUrl+"/confirm-email",
+		),
+		logger,
+		validator.New(),
+	)
+
+	passwordHandler := password.NewPasswordHandler(
+		ports.NewPasswordService(
+			credentialStore,
+			postgres.NewUserMetadataStore(pgq),
+			redis.NewResetStore(redisClient),
+			refreshStore,
+			accessDenylist,
+			personalTokenService,
+			passwordPolicy,
+			passwordHasher,
+			mailer,
+			activityRecorder,
+			appUrl+"/reset-password",
 		),
 		logger,
 		validator.New(),
@@ -70,17 +361,153 @@ func main() {
 		validator.New(),
 	)
 
+	memberService := boardPorts.NewMemberService(boardPostgres.NewMemberStore(boardPgq), credentialStore, verifyEmailForInvites)
+
+	// events go out through Redis, and the relay hands the events of every instance to the local
+	// hub, which buffers up to 64 events per subscriber before dropping it
+	hub := boardNative.NewHub(64)
+	eventPublisher := boardRedis.NewEventPublisher(redisClient, logger)
+
+	go func() {
+		err := boardRedis.NewEventRelay(redisClient, hub, logger).Run(context.Background())
+		if err != nil {
+			logger.Error(fmt.Sprintf("Can't relay board events. %s", err.Error()))
+			os.Exit(1)
+		}
+	}()
+
+	accessHandler := access.NewAccessHandler(memberService, logger)
+
+	memberHandler := member.NewMemberHandler(
+		memberService,
+		logger,
+		validator.New(),
+	)
+
+	boardHandler := board.NewBoardHandler(
+		boardPorts.NewBoardService(boardPostgres.NewBoardStore(pg), activityRecorder),
+		logger,
+		validator.New(),
+	)
+
+	columnHandler := column.NewColumnHandler(
+		boardPorts.NewColumnService(boardPostgres.NewColumnStore(pg), eventPublisher),
+		logger,
+		validator.New(),
+	)
+
+	cardHandler := card.NewCardHandler(
+		boardPorts.NewCardService(boardPostgres.NewCardStore(pg), eventPublisher, activityRecorder),
+		logger,
+		validator.New(),
+	)
+
+	activityHandler := activity.NewActivityHandler(activityService, logger)
+
+	eventsHandler := events.NewEventsHandler(hub, boardRedis.NewEventLog(redisClient), logger)
+
+	// withScope authenticates the request and makes sure its access token has scope
+	withScope := func(scope string, h http.HandlerFunc) http.Handler {
+		return authHandler.AuthMiddleware(authHandler.RequireScope(scope, h))
+	}
+
+	// onBoards authenticates the request and makes sure its access token may read boards, or
+	// change them for anything but GET requests
+	onBoards := func(h http.Handler) http.Handler {
+		read := authHandler.RequireScope(domain.ScopeBoardsRead, h)
+		write := authHandler.RequireScope(domain.ScopeBoardsWrite, h)
+
+		return authHandler.AuthMiddleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				read.ServeHTTP(rw, r)
+				return
+			}
+			write.ServeHTTP(rw, r)
+		}))
+	}
+
+	// onBoard does what onBoards does, and makes sure the user has at least role on the board in the route
+	onBoard := func(role boardDomain.Role, h http.HandlerFunc) http.Handler {
+		return onBoards(accessHandler.Require(role, h))
+	}
+
 	router := mux.NewRouter()
 
 	router.Use(logger.Middleware)
 
+	router.HandleFunc("/.well-known/jwks.json", authHandler.JWKS).Methods(http.MethodGet)
+
 	router.HandleFunc("/users/signup", authHandler.SignUp).Methods(http.MethodPost)
 	router.HandleFunc("/users/login", authHandler.LogIn).Methods(http.MethodPost)
+	router.HandleFunc("/users/login/mfa", authHandler.LogInMFA).Methods(http.MethodPost)
+	router.HandleFunc("/users/oauth/{provider}", oauthHandler.Authorize).Methods(http.MethodGet)
+	router.HandleFunc("/users/oauth/{provider}/callback", oauthHandler.Callback).Methods(http.MethodPost)
 	router.HandleFunc("/users/refresh", authHandler.RefreshAccessToken).Methods(http.MethodPost)
-	router.Handle("/users/logout", authHandler.AuthMiddleware(http.HandlerFunc(authHandler.LogOut))).Methods(http.MethodPost)
+	router.Handle("/users/logout", withScope(domain.ScopeAccount, authHandler.LogOut)).Methods(http.MethodPost)
+
+	router.HandleFunc("/users/restore", accountHandler.Restore).Methods(http.MethodPost)
+
+	router.HandleFunc("/users/verify-email", verificationHandler.Verify).Methods(http.MethodPost)
+	router.HandleFunc("/users/verify-email/resend", verificationHandler.Resend).Methods(http.MethodPost)
+
+	router.Handle("/users/me/password", withScope(domain.ScopeAccount, passwordHandler.Change)).Methods(http.MethodPut)
+	router.HandleFunc("/users/password/forgot", passwordHandler.Forgot).Methods(http.MethodPost)
+	router.HandleFunc("/users/password/reset", passwordHandler.Reset).Methods(http.MethodPost)
+
+	router.Handle("/users/me/email", withScope(domain.ScopeAccount, emailHandler.Change)).Methods(http.MethodPut)
+	router.HandleFunc("/users/email/confirm", emailHandler.Confirm).Methods(http.MethodPost)
+
+	router.Handle("/users/me/mfa/totp", withScope(domain.ScopeAccount, mfaHandler.Enroll)).Methods(http.MethodPost)
+	router.Handle("/users/me/mfa/totp/confirm", withScope(domain.ScopeAccount, mfaHandler.Confirm)).Methods(http.MethodPost)
+	router.Handle("/users/me/mfa/totp", withScope(domain.ScopeAccount, mfaHandler.Disable)).Methods(http.MethodDelete)
+
+	router.Handle("/users/me/identities/{provider}", withScope(domain.ScopeAccount, oauthHandler.AuthorizeLink)).Methods(http.MethodPost)
+	router.Handle("/users/me/identities/{provider}/callback", withScope(domain.ScopeAccount, oauthHandler.LinkCallback)).Methods(http.MethodPost)
+
+	router.Handle("/users/me/sessions", withScope(domain.ScopeAccount, sessionHandler.List)).Methods(http.MethodGet)
+	router.Handle("/users/me/sessions", withScope(domain.ScopeAccount, sessionHandler.RevokeAll)).Methods(http.MethodDelete)
+	router.Handle("/users/me/sessions/{session_id}", withScope(domain.ScopeAccount, sessionHandler.Revoke)).Methods(http.MethodDelete)
+
+	router.Handle("/users/me/tokens", withScope(domain.ScopeAccount, tokenHandler.Create)).Methods(http.MethodPost)
+	router.Handle("/users/me/tokens", withScope(domain.ScopeAccount, tokenHandler.List)).Methods(http.MethodGet)
+	router.Handle("/users/me/tokens/{token_id}", withScope(domain.ScopeAccount, tokenHandler.Revoke)).Methods(http.MethodDelete)
 
 	router.HandleFunc("/users/{user_id}", userHandler.Get).Methods(http.MethodGet)
-	router.Handle("/users/{user_id}", authHandler.AuthMiddleware(http.HandlerFunc(userHandler.Update))).Methods(http.MethodPut)
+	router.Handle("/users/{user_id}", withScope(domain.ScopeAccount, userHandler.Update)).Methods(http.MethodPut)
+	router.Handle("/users/{user_id}", withScope(domain.ScopeAccount, accountHandler.Delete)).Methods(http.MethodDelete)
+	router.Handle("/users/{user_id}/lock", withScope(domain.ScopeAccount, accountHandler.Lock)).Methods(http.MethodPost)
+	router.Handle("/users/{user_id}/unlock", withScope(domain.ScopeAccount, accountHandler.Unlock)).Methods(http.MethodPost)
+
+	router.Handle("/users/{user_id}/activity", withScope(domain.ScopeAccount, activityHandler.ListByUser)).Methods(http.MethodGet)
+
+	router.Handle("/boards", onBoards(http.HandlerFunc(boardHandler.Create))).Methods(http.MethodPost)
+	router.Handle("/boards", onBoards(http.HandlerFunc(boardHandler.List))).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}", onBoard(boardDomain.RoleViewer, boardHandler.Get)).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}", onBoard(boardDomain.RoleAdmin, boardHandler.Update)).Methods(http.MethodPut)
+	router.Handle("/boards/{board_id}", onBoard(boardDomain.RoleOwner, boardHandler.Delete)).Methods(http.MethodDelete)
+
+	router.Handle("/boards/{board_id}/activity", onBoard(boardDomain.RoleViewer, activityHandler.ListByBoard)).Methods(http.MethodGet)
+
+	router.Handle("/boards/{board_id}/members", onBoard(boardDomain.RoleViewer, memberHandler.List)).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}/members", onBoard(boardDomain.RoleAdmin, memberHandler.Invite)).Methods(http.MethodPost)
+	router.Handle("/boards/{board_id}/members/{user_id}", onBoard(boardDomain.RoleAdmin, memberHandler.ChangeRole)).Methods(http.MethodPut)
+	router.Handle("/boards/{board_id}/members/{user_id}", onBoard(boardDomain.RoleViewer, memberHandler.Remove)).Methods(http.MethodDelete)
+
+	router.Handle("/boards/{board_id}/columns", onBoard(boardDomain.RoleViewer, columnHandler.List)).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}/columns", onBoard(boardDomain.RoleEditor, columnHandler.Create)).Methods(http.MethodPost)
+	router.Handle("/boards/{board_id}/columns/{column_id}", onBoard(boardDomain.RoleEditor, columnHandler.Rename)).Methods(http.MethodPut)
+	router.Handle("/boards/{board_id}/columns/{column_id}", onBoard(boardDomain.RoleAdmin, columnHandler.Delete)).Methods(http.MethodDelete)
+	router.Handle("/boards/{board_id}/columns/{column_id}/position", onBoard(boardDomain.RoleEditor, columnHandler.Move)).Methods(http.MethodPut)
+
+	router.Handle("/boards/{board_id}/cards", onBoard(boardDomain.RoleViewer, cardHandler.List)).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}/columns/{column_id}/cards", onBoard(boardDomain.RoleEditor, cardHandler.Create)).Methods(http.MethodPost)
+	router.Handle("/boards/{board_id}/cards/{card_id}", onBoard(boardDomain.RoleViewer, cardHandler.Get)).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}/cards/{card_id}", onBoard(boardDomain.RoleEditor, cardHandler.Update)).Methods(http.MethodPut)
+	router.Handle("/boards/{board_id}/cards/{card_id}", onBoard(boardDomain.RoleEditor, cardHandler.Delete)).Methods(http.MethodDelete)
+	router.Handle("/boards/{board_id}/cards/{card_id}/position", onBoard(boardDomain.RoleEditor, cardHandler.Move)).Methods(http.MethodPut)
+
+	router.Handle("/boards/{board_id}/events", onBoard(boardDomain.RoleViewer, eventsHandler.Stream)).Methods(http.MethodGet)
+	router.Handle("/boards/{board_id}/events/stream", onBoard(boardDomain.RoleViewer, eventsHandler.ServerSentEvents)).Methods(http.MethodGet)
 
 	logger.Debug(fmt.Sprintf("Starting server on port: %s", port))
 
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+type Type string
+
+const (
+	TypeUserSignedUp                   Type = "user.signed_up"
+	TypeUserLoggedIn                   Type = "user.logged_in"
+	TypeUserLoggedOut                  Type = "user.logged_out"
+	TypeUserLoggedOutEverywhere        Type = "user.logged_out_everywhere"
+	TypeUserTokenRefreshed             Type = "user.token_refreshed"
+	TypeUserPasswordChanged            Type = "user.password_changed"
+	TypeUserPasswordResetRequested     Type = "user.password_reset_requested"
+	TypeUserPasswordReset              Type = "user.password_reset"
+	TypeUserEmailVerified              Type = "user.email_verified"
+	TypeUserDeleted                    Type = "user.deleted"
+	TypeUserRestored                   Type = "user.restored"
+	TypeUserPurged                     Type = "user.purged"
+	TypeUserMFAEnabled                 Type = "user.mfa_enabled"
+	TypeUserMFADisabled                Type = "user.mfa_disabled"
+	TypeUserIdentityLinked             Type = "user.identity_linked"
+	TypeUserLocked                     Type = "user.locked"
+	TypeUserUnlocked                   Type = "user.unlocked"
+	TypeUserEmailChanged               Type = "user.email_changed"
+	TypeUserPersonalAccessTokenCreated Type = "user.personal_access_token_created"
+	TypeUserPersonalAccessTokenRevoked Type = "user.personal_access_token_revoked"
+	TypeBoardCreated                   Type = "board.created"
+	TypeBoardUpdated                   Type = "board.updated"
+	TypeBoardDeleted                   Type = "board.deleted"
+	TypeCardCreated                    Type = "card.created"
+	TypeCardUpdated                    Type = "card.updated"
+	TypeCardMoved                      Type = "card.moved"
+	TypeCardDeleted                    Type = "card.deleted"
+)
+
+// Activity records that ActorID did something to SubjectID: a user for account activity, a
+// board or card for board activity. BoardID is empty for activity that is not about a board.
+type Activity struct {
+	ActivityID int64
+	ActorID    string
+	BoardID    string
+	Type       Type
+	SubjectID  string
+	Details    map[string]string
+	CreatedAt  time.Time
+}
+
+// Filter narrows a listing down. Zero fields do not filter; Before pages through the results by
+// only returning activity older than the given ActivityID.
+type Filter struct {
+	BoardID string
+	ActorID string
+	Types   []Type
+	Before  int64
+	Limit   int
+}
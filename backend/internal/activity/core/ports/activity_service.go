@@ -0,0 +1,71 @@
+package ports
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/activity"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+type ActivityService struct {
+	store ActivityStore
+}
+
+func NewActivityService(store ActivityStore) *ActivityService {
+	return &ActivityService{store}
+}
+
+func (s *ActivityService) Record(entry *domain.Activity) error {
+	op := "ports.ActivityService.Record"
+
+	if entry.ActorID == "" || entry.Type == "" || entry.SubjectID == "" {
+		return &activity.Error{Op: op, Code: activity.EINVALID, Message: "activity needs an actor, a type and a subject"}
+	}
+
+	_, err := s.store.Insert(entry)
+	if err != nil {
+		return &activity.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+// ListByBoard returns the activity on the board, newest first.
+func (s *ActivityService) ListByBoard(boardID string, filter *domain.Filter) ([]*domain.Activity, error) {
+	op := "ports.ActivityService.ListByBoard"
+
+	filter.BoardID = boardID
+	entries, err := s.list(filter)
+	if err != nil {
+		return nil, &activity.Error{Op: op, Err: err}
+	}
+
+	return entries, nil
+}
+
+// ListByUser returns the activity of the user on any board and on their account, newest first.
+func (s *ActivityService) ListByUser(userID string, filter *domain.Filter) ([]*domain.Activity, error) {
+	op := "ports.ActivityService.ListByUser"
+
+	filter.ActorID = userID
+	entries, err := s.list(filter)
+	if err != nil {
+		return nil, &activity.Error{Op: op, Err: err}
+	}
+
+	return entries, nil
+}
+
+func (s *ActivityService) list(filter *domain.Filter) ([]*domain.Activity, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+
+	return s.store.List(filter)
+}
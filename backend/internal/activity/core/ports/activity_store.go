@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+
+type ActivityStore interface {
+	Insert(*domain.Activity) (*domain.Activity, error)
+	// List returns the activity matching filter, newest first.
+	List(filter *domain.Filter) ([]*domain.Activity, error)
+}
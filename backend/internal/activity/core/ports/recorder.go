@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+
+// Recorder is what other modules write their activity to.
+type Recorder interface {
+	// Record is best effort: the action the activity describes is already done, so failing to
+	// write it down must not fail the request that did it.
+	Record(*domain.Activity)
+}
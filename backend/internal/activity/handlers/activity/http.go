@@ -0,0 +1,125 @@
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	activity *ports.ActivityService
+	log      logging.Logger
+}
+
+func NewActivityHandler(activity *ports.ActivityService, log logging.Logger) *Handler {
+	return &Handler{activity, log}
+}
+
+// ListByBoard pages through the activity of the board. It can be filtered by actor_id and by one
+// or more type parameters, and continued with before.
+func (h *Handler) ListByBoard(rw http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["board_id"]
+
+	filter, ok := parseFilter(h, rw, r)
+	if !ok {
+		return
+	}
+	filter.ActorID = r.URL.Query().Get("actor_id")
+
+	entries, err := h.activity.ListByBoard(boardID, filter)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("board activity fetch succesfull. boardId: %s", boardID))
+	h.writeJSON(rw, toPageResponse(entries, filter.Limit))
+}
+
+// ListByUser pages through what the user did. Users can only see their own activity.
+func (h *Handler) ListByUser(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	rUserID := mux.Vars(r)["user_id"]
+
+	if loggedInUserID != rUserID {
+		h.log.Debug(fmt.Sprintf("user %s cannot view activity of user %s", loggedInUserID, rUserID))
+
+		http.Error(rw, "cannot view activity of a different user", http.StatusForbidden)
+		return
+	}
+
+	filter, ok := parseFilter(h, rw, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.activity.ListByUser(rUserID, filter)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("user activity fetch succesfull. userId: %s", rUserID))
+	h.writeJSON(rw, toPageResponse(entries, filter.Limit))
+}
+
+func parseFilter(h *Handler, rw http.ResponseWriter, r *http.Request) (*domain.Filter, bool) {
+	query := r.URL.Query()
+	filter := &domain.Filter{}
+
+	for _, t := range query["type"] {
+		filter.Types = append(filter.Types, domain.Type(t))
+	}
+
+	if before := query.Get("before"); before != "" {
+		parsed, err := strconv.ParseInt(before, 10, 64)
+		if err != nil || parsed < 1 {
+			h.log.Debug(fmt.Sprintf("invalid before parameter (%s)", before))
+
+			http.Error(rw, "invalid request. before must be an activity id", http.StatusBadRequest)
+			return nil, false
+		}
+		filter.Before = parsed
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 {
+			h.log.Debug(fmt.Sprintf("invalid limit parameter (%s)", limit))
+
+			http.Error(rw, "invalid request. limit must be a positive number", http.StatusBadRequest)
+			return nil, false
+		}
+		filter.Limit = parsed
+	}
+
+	return filter, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch activity.ErrorCode(err) {
+	case activity.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, activity.ErrorMessage(err), http.StatusBadRequest)
+	case activity.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, activity.ErrorMessage(err), http.StatusNotFound)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, activity.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
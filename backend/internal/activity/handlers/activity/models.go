@@ -0,0 +1,42 @@
+package activity
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	"time"
+)
+
+type ActivityResponse struct {
+	ActivityID int64             `json:"activity_id"`
+	ActorID    string            `json:"actor_id"`
+	BoardID    string            `json:"board_id,omitempty"`
+	Type       domain.Type       `json:"type"`
+	SubjectID  string            `json:"subject_id"`
+	Details    map[string]string `json:"details,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+type PageResponse struct {
+	Activity []*ActivityResponse `json:"activity"`
+	// NextBefore is the before parameter for the next page, absent on the last page.
+	NextBefore int64 `json:"next_before,omitempty"`
+}
+
+func toPageResponse(entries []*domain.Activity, limit int) *PageResponse {
+	response := &PageResponse{Activity: make([]*ActivityResponse, 0, len(entries))}
+	for _, entry := range entries {
+		response.Activity = append(response.Activity, &ActivityResponse{
+			ActivityID: entry.ActivityID,
+			ActorID:    entry.ActorID,
+			BoardID:    entry.BoardID,
+			Type:       entry.Type,
+			SubjectID:  entry.SubjectID,
+			Details:    entry.Details,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+
+	if len(entries) != 0 && len(entries) == limit {
+		response.NextBefore = entries[len(entries)-1].ActivityID
+	}
+	return response
+}
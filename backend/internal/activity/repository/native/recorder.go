@@ -0,0 +1,26 @@
+package native
+
+import (
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+)
+
+// Recorder writes the activity of other modules through the activity service, logging activity
+// it cannot write.
+type Recorder struct {
+	activity *ports.ActivityService
+	log      logging.Logger
+}
+
+func NewRecorder(activity *ports.ActivityService, log logging.Logger) *Recorder {
+	return &Recorder{activity, log}
+}
+
+func (r *Recorder) Record(entry *domain.Activity) {
+	err := r.activity.Record(entry)
+	if err != nil {
+		r.log.Warn(fmt.Sprintf("unable to record activity. type: %s, subjectId: %s, err: %s", entry.Type, entry.SubjectID, err.Error()))
+	}
+}
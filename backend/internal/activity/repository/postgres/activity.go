@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/activity/repository/postgres/activity/dao"
+	"github.com/hardiksachan/kanban_board/backend/shared"
+	"github.com/jackc/pgtype"
+)
+
+type ActivityStore struct {
+	q *dao.Queries
+}
+
+func NewActivityStore(q *dao.Queries) *ActivityStore {
+	return &ActivityStore{q}
+}
+
+func (s *ActivityStore) Insert(entry *domain.Activity) (*domain.Activity, error) {
+	op := "postgres.ActivityStore.Insert"
+
+	actorUuid, err := parseUUID(op, entry.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	subjectUuid, err := parseUUID(op, entry.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	boardUuid, err := parseNullUUID(op, entry.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := entry.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	encodedDetails, err := json.Marshal(details)
+	if err != nil {
+		return nil, &activity.Error{Code: activity.EINTERNAL, Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbActivity, err := s.q.InsertActivity(ctx, dao.InsertActivityParams{
+		ActorID:   *actorUuid,
+		BoardID:   boardUuid,
+		Type:      string(entry.Type),
+		SubjectID: *subjectUuid,
+		Details:   pgtype.JSONB{Bytes: encodedDetails, Status: pgtype.Present},
+	})
+	if err != nil {
+		return nil, &activity.Error{Code: activity.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainActivity(op, dbActivity)
+}
+
+func (s *ActivityStore) List(filter *domain.Filter) ([]*domain.Activity, error) {
+	op := "postgres.ActivityStore.List"
+
+	boardUuid, err := parseNullUUID(op, filter.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	actorUuid, err := parseNullUUID(op, filter.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]string, 0, len(filter.Types))
+	for _, t := range filter.Types {
+		types = append(types, string(t))
+	}
+
+	ctx := context.Background()
+	dbActivities, err := s.q.ListActivity(ctx, dao.ListActivityParams{
+		BoardID:  boardUuid,
+		ActorID:  actorUuid,
+		Types:    types,
+		Before:   filter.Before,
+		RowLimit: int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, &activity.Error{Code: activity.EINTERNAL, Op: op, Err: err}
+	}
+
+	result := make([]*domain.Activity, 0, len(dbActivities))
+	for _, dbActivity := range dbActivities {
+		entry, err := toDomainActivity(op, dbActivity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func toDomainActivity(op string, dbActivity dao.Activity) (*domain.Activity, error) {
+	details := map[string]string{}
+	err := json.Unmarshal(dbActivity.Details.Bytes, &details)
+	if err != nil {
+		return nil, &activity.Error{Code: activity.EINTERNAL, Op: op, Err: err}
+	}
+
+	boardID := ""
+	if dbActivity.BoardID.Valid {
+		boardID = dbActivity.BoardID.UUID.String()
+	}
+
+	return &domain.Activity{
+		ActivityID: dbActivity.ActivityID,
+		ActorID:    dbActivity.ActorID.String(),
+		BoardID:    boardID,
+		Type:       domain.Type(dbActivity.Type),
+		SubjectID:  dbActivity.SubjectID.String(),
+		Details:    details,
+		CreatedAt:  dbActivity.CreatedAt,
+	}, nil
+}
+
+func parseUUID(op, id string) (*uuid.UUID, error) {
+	parsed, err := shared.GetUUIDFromString(id)
+	if err != nil {
+		return nil, &activity.Error{Code: activity.EINVALID, Message: fmt.Sprintf("Unable to parse UUID. id: %v", id), Op: op, Err: err}
+	}
+	return parsed, nil
+}
+
+// parseNullUUID maps an empty id to NULL.
+func parseNullUUID(op, id string) (uuid.NullUUID, error) {
+	if id == "" {
+		return uuid.NullUUID{}, nil
+	}
+
+	parsed, err := parseUUID(op, id)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: *parsed, Valid: true}, nil
+}
@@ -0,0 +1,14 @@
+-- name: InsertActivity :one
+INSERT INTO activity (actor_id, board_id, type, subject_id, details)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListActivity :many
+SELECT *
+FROM activity
+WHERE (sqlc.narg(board_id)::UUID IS NULL OR board_id = sqlc.narg(board_id)::UUID)
+  AND (sqlc.narg(actor_id)::UUID IS NULL OR actor_id = sqlc.narg(actor_id)::UUID)
+  AND (cardinality(@types::TEXT[]) = 0 OR type = ANY (@types::TEXT[]))
+  AND (@before::BIGINT = 0 OR activity_id < @before::BIGINT)
+ORDER BY activity_id DESC
+LIMIT @row_limit::INTEGER;
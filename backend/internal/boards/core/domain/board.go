@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type Board struct {
+	BoardID     string
+	OwnerID     string
+	Title       string
+	Description string
+	Version     int32
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
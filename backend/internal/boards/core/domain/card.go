@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type Card struct {
+	CardID      string
+	BoardID     string
+	ColumnID    string
+	Title       string
+	Description string
+	AssigneeIDs []string
+	DueDate     *time.Time
+	Rank        string
+	// Version is bumped on every change and must match the stored version for an update to apply.
+	Version    int32
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
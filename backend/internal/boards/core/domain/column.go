@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type Column struct {
+	ColumnID   string
+	BoardID    string
+	Title      string
+	Rank       string
+	Version    int32
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
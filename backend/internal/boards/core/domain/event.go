@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventColumnCreated EventType = "column.created"
+	EventColumnRenamed EventType = "column.renamed"
+	EventColumnMoved   EventType = "column.moved"
+	EventColumnDeleted EventType = "column.deleted"
+	EventCardCreated   EventType = "card.created"
+	EventCardUpdated   EventType = "card.updated"
+	EventCardMoved     EventType = "card.moved"
+	EventCardDeleted   EventType = "card.deleted"
+)
+
+// Event tells board subscribers that something on the board changed. Exactly one of Column and
+// Card is set, holding the entity as it is after the change (or right before, for deletes).
+type Event struct {
+	// ID orders the events of a board. It is assigned by the event log when the event is
+	// published, and stays empty for events that are not logged.
+	ID         string
+	Type       EventType
+	BoardID    string
+	Column     *Column
+	Card       *Card
+	OccurredAt time.Time
+}
+
+func NewColumnEvent(eventType EventType, column *Column) *Event {
+	return &Event{
+		Type:       eventType,
+		BoardID:    column.BoardID,
+		Column:     column,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+func NewCardEvent(eventType EventType, card *Card) *Event {
+	return &Event{
+		Type:       eventType,
+		BoardID:    card.BoardID,
+		Card:       card,
+		OccurredAt: time.Now().UTC(),
+	}
+}
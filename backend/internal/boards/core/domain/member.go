@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+	RoleOwner  Role = "owner"
+)
+
+// roleLevels orders roles so that every role includes the permissions of the ones below it.
+var roleLevels = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleLevels[r]
+	return ok
+}
+
+// Includes reports whether r grants at least the permissions of other.
+func (r Role) Includes(other Role) bool {
+	return r.Valid() && roleLevels[r] >= roleLevels[other]
+}
+
+type Member struct {
+	BoardID   string
+	UserID    string
+	Name      string
+	Email     string
+	Role      Role
+	CreatedAt time.Time
+}
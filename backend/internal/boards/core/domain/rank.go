@@ -0,0 +1,61 @@
+package domain
+
+// Ranks are lowercase strings that order items lexicographically. A new rank can always be made
+// between two existing ones, so moving an item only ever rewrites the item itself.
+const (
+	rankBegin = 'a' - 1 // sentinel before the first digit
+	rankFirst = 'a'
+	rankLast  = 'z'
+	rankEnd   = 'z' + 1 // sentinel after the last digit
+)
+
+// RankBetween returns a rank that sorts strictly between prev and next. An empty prev means the
+// start of the list and an empty next means the end of it. prev must sort before next, and
+// neither may end in 'a'; ranks returned by RankBetween never do.
+func RankBetween(prev, next string) string {
+	var p, n int
+	pos := 0
+
+	// find the leftmost position where prev and next differ
+	for p == n {
+		p = rankDigit(prev, pos, rankBegin)
+		n = rankDigit(next, pos, rankEnd)
+		pos++
+	}
+
+	rank := []byte(prev[:pos-1])
+
+	if p == rankBegin {
+		// prev is a prefix of next, match next's leading 'a's so there is room below it
+		for n == rankFirst {
+			n = rankDigit(next, pos, rankEnd)
+			pos++
+			rank = append(rank, rankFirst)
+		}
+		if n == rankFirst+1 {
+			rank = append(rank, rankFirst)
+			n = rankEnd
+		}
+	} else if p+1 == n {
+		// digits are consecutive, keep prev's digit and find room after the rest of prev
+		rank = append(rank, byte(p))
+		n = rankEnd
+		for {
+			p = rankDigit(prev, pos, rankBegin)
+			pos++
+			if p != rankLast {
+				break
+			}
+			rank = append(rank, rankLast)
+		}
+	}
+
+	return string(append(rank, byte((p+n+1)/2)))
+}
+
+func rankDigit(rank string, pos int, fallback int) int {
+	if pos < len(rank) {
+		return int(rank[pos])
+	}
+	return fallback
+}
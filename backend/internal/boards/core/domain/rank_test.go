@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next string
+	}{
+		{"empty list", "", ""},
+		{"before first", "", "n"},
+		{"after last", "n", ""},
+		{"between distant", "c", "x"},
+		{"between consecutive", "m", "n"},
+		{"between consecutive with tail", "mz", "n"},
+		{"prev prefix of next", "m", "mn"},
+		{"prev prefix of next starting with b", "m", "mb"},
+		{"prev prefix of next with leading a", "m", "mab"},
+		{"before rank starting with b", "", "b"},
+		{"before rank starting with ab", "", "ab"},
+		{"after z", "z", ""},
+		{"after zz", "zz", ""},
+		{"between long ranks", "abcz", "abd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank := RankBetween(tt.prev, tt.next)
+			checkRank(t, tt.prev, rank, tt.next)
+		})
+	}
+}
+
+func TestRankBetweenRepeated(t *testing.T) {
+	tests := []struct {
+		name  string
+		first string
+		// step returns the bounds of the next rank given the last rank made
+		step func(last string) (string, string)
+	}{
+		{"always first", "n", func(last string) (string, string) { return "", last }},
+		{"always last", "n", func(last string) (string, string) { return last, "" }},
+		{"closing in from above", "o", func(last string) (string, string) { return "n", last }},
+		{"closing in from below", "m", func(last string) (string, string) { return last, "n" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last := tt.first
+			for i := 0; i < 200; i++ {
+				prev, next := tt.step(last)
+				rank := RankBetween(prev, next)
+				checkRank(t, prev, rank, next)
+				last = rank
+			}
+		})
+	}
+}
+
+func checkRank(t *testing.T, prev, rank, next string) {
+	t.Helper()
+
+	if rank <= prev || (next != "" && rank >= next) {
+		t.Fatalf("RankBetween(%q, %q) = %q, want a rank strictly between them", prev, next, rank)
+	}
+	if strings.HasSuffix(rank, string(rune(rankFirst))) {
+		t.Fatalf("RankBetween(%q, %q) = %q, ends in %q", prev, next, rank, rankFirst)
+	}
+	if strings.Trim(rank, "abcdefghijklmnopqrstuvwxyz") != "" {
+		t.Fatalf("RankBetween(%q, %q) = %q, want lowercase letters only", prev, next, rank)
+	}
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+)
+
+// record writes activity on a board to the activity module.
+func record(recorder activityPorts.Recorder, activityType activityDomain.Type, actorID, boardID, subjectID string, details map[string]string) {
+	recorder.Record(&activityDomain.Activity{
+		ActorID:   actorID,
+		BoardID:   boardID,
+		Type:      activityType,
+		SubjectID: subjectID,
+		Details:   details,
+	})
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type Authorizer interface {
+	// Authorize returns the user's membership of the board if it grants at least role. Boards
+	// the user is not a member of are reported as boards.ENOTFOUND, so their existence is not
+	// leaked; insufficient roles are reported as boards.EFORBIDDEN.
+	Authorize(boardID, userID string, role domain.Role) (*domain.Member, error)
+}
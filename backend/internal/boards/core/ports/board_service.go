@@ -0,0 +1,82 @@
+package ports
+
+import (
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+)
+
+type BoardService struct {
+	store    BoardStore
+	activity activityPorts.Recorder
+}
+
+func NewBoardService(store BoardStore, activity activityPorts.Recorder) *BoardService {
+	return &BoardService{store, activity}
+}
+
+func (s *BoardService) Create(board *domain.Board) (*domain.Board, error) {
+	op := "ports.BoardService.Create"
+
+	storedBoard, err := s.store.Insert(board)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeBoardCreated, storedBoard.OwnerID, storedBoard.BoardID, storedBoard.BoardID, map[string]string{
+		"title": storedBoard.Title,
+	})
+
+	return storedBoard, nil
+}
+
+func (s *BoardService) Update(actorID string, board *domain.Board) (*domain.Board, error) {
+	op := "ports.BoardService.Update"
+
+	storedBoard, err := s.store.Update(board)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeBoardUpdated, actorID, storedBoard.BoardID, storedBoard.BoardID, map[string]string{
+		"title": storedBoard.Title,
+	})
+
+	return storedBoard, nil
+}
+
+func (s *BoardService) Delete(actorID, boardID string) error {
+	op := "ports.BoardService.Delete"
+
+	err := s.store.Remove(boardID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeBoardDeleted, actorID, boardID, boardID, nil)
+
+	return nil
+}
+
+func (s *BoardService) Find(boardID string) (*domain.Board, error) {
+	op := "ports.BoardService.Find"
+
+	board, err := s.store.Get(boardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return board, nil
+}
+
+func (s *BoardService) ListByMember(userID string) ([]*domain.Board, error) {
+	op := "ports.BoardService.ListByMember"
+
+	memberBoards, err := s.store.ListByMember(userID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return memberBoards, nil
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type BoardStore interface {
+	// Insert stores the board and makes its owner a member with the owner role.
+	Insert(*domain.Board) (*domain.Board, error)
+	Update(*domain.Board) (*domain.Board, error)
+	Remove(boardID string) error
+	Get(boardID string) (*domain.Board, error)
+	ListByMember(userID string) ([]*domain.Board, error)
+}
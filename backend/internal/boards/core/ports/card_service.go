@@ -0,0 +1,133 @@
+package ports
+
+import (
+	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+)
+
+type CardService struct {
+	store     CardStore
+	publisher EventPublisher
+	activity  activityPorts.Recorder
+}
+
+func NewCardService(store CardStore, publisher EventPublisher, activity activityPorts.Recorder) *CardService {
+	return &CardService{store, publisher, activity}
+}
+
+func (s *CardService) Create(actorID string, card *domain.Card) (*domain.Card, error) {
+	op := "ports.CardService.Create"
+
+	storedCard, err := s.store.Insert(card)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeCardCreated, actorID, storedCard.BoardID, storedCard.CardID, map[string]string{
+		"title":     storedCard.Title,
+		"column_id": storedCard.ColumnID,
+	})
+	s.publisher.Publish(domain.NewCardEvent(domain.EventCardCreated, storedCard))
+
+	return storedCard, nil
+}
+
+func (s *CardService) Update(actorID string, card *domain.Card) (*domain.Card, error) {
+	op := "ports.CardService.Update"
+
+	_, err := s.Find(card.BoardID, card.CardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	updatedCard, err := s.store.Update(card)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeCardUpdated, actorID, updatedCard.BoardID, updatedCard.CardID, map[string]string{
+		"title": updatedCard.Title,
+	})
+	s.publisher.Publish(domain.NewCardEvent(domain.EventCardUpdated, updatedCard))
+
+	return updatedCard, nil
+}
+
+func (s *CardService) Delete(actorID, boardID, cardID string) error {
+	op := "ports.CardService.Delete"
+
+	card, err := s.Find(boardID, cardID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	err = s.store.Remove(cardID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeCardDeleted, actorID, card.BoardID, card.CardID, map[string]string{
+		"title": card.Title,
+	})
+	s.publisher.Publish(domain.NewCardEvent(domain.EventCardDeleted, card))
+
+	return nil
+}
+
+// Move puts the card into toColumnID right before beforeCardID, or at the end of the column when
+// beforeCardID is empty. version is the card version the caller last saw; if someone else changed
+// the card in the meantime the move is rejected with boards.ECONFLICT.
+func (s *CardService) Move(actorID, boardID, cardID, toColumnID, beforeCardID string, version int32) (*domain.Card, error) {
+	op := "ports.CardService.Move"
+
+	if cardID == beforeCardID {
+		return nil, &boards.Error{Op: op, Code: boards.EINVALID, Message: "card cannot be moved before itself"}
+	}
+
+	card, err := s.Find(boardID, cardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	movedCard, err := s.store.Move(cardID, toColumnID, beforeCardID, version)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	record(s.activity, activityDomain.TypeCardMoved, actorID, movedCard.BoardID, movedCard.CardID, map[string]string{
+		"from_column_id": card.ColumnID,
+		"to_column_id":   movedCard.ColumnID,
+	})
+	s.publisher.Publish(domain.NewCardEvent(domain.EventCardMoved, movedCard))
+
+	return movedCard, nil
+}
+
+// Find returns the card only if it belongs to the given board.
+func (s *CardService) Find(boardID, cardID string) (*domain.Card, error) {
+	op := "ports.CardService.Find"
+
+	card, err := s.store.Get(cardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	if card.BoardID != boardID {
+		return nil, &boards.Error{Op: op, Code: boards.ENOTFOUND, Message: fmt.Sprintf("card (%s) does not exist on board (%s)", cardID, boardID)}
+	}
+
+	return card, nil
+}
+
+func (s *CardService) ListByBoard(boardID string) ([]*domain.Card, error) {
+	op := "ports.CardService.ListByBoard"
+
+	cards, err := s.store.ListByBoard(boardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return cards, nil
+}
@@ -0,0 +1,102 @@
+package ports_test
+
+import (
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/repository/native"
+	"testing"
+)
+
+// cardStore holds cards in memory. Calls it doesn't implement panic through the nil interface.
+type cardStore struct {
+	ports.CardStore
+	cards map[string]*domain.Card
+}
+
+func (s *cardStore) Get(cardID string) (*domain.Card, error) {
+	card, ok := s.cards[cardID]
+	if !ok {
+		return nil, &boards.Error{Code: boards.ENOTFOUND}
+	}
+	stored := *card
+	return &stored, nil
+}
+
+func (s *cardStore) Move(cardID, toColumnID, beforeCardID string, version int32) (*domain.Card, error) {
+	card, ok := s.cards[cardID]
+	if !ok {
+		return nil, &boards.Error{Code: boards.ENOTFOUND}
+	}
+	if card.Version != version {
+		return nil, &boards.Error{Code: boards.ECONFLICT}
+	}
+	card.ColumnID = toColumnID
+	card.Version++
+	moved := *card
+	return &moved, nil
+}
+
+type recorder struct{}
+
+func (recorder) Record(*activityDomain.Activity) {}
+
+func newCardService(publisher *native.EventPublisher) *ports.CardService {
+	store := &cardStore{cards: map[string]*domain.Card{
+		"card": {CardID: "card", BoardID: "board", ColumnID: "todo", Version: 1},
+	}}
+	return ports.NewCardService(store, publisher, recorder{})
+}
+
+func TestCardServiceMovePublishesEvent(t *testing.T) {
+	publisher := native.NewEventPublisher()
+	cards := newCardService(publisher)
+
+	moved, err := cards.Move("user", "board", "card", "done", "", 1)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+
+	published := publisher.Published()
+	if len(published) != 1 {
+		t.Fatalf("got %d events, want 1", len(published))
+	}
+	event := published[0]
+	if event.Type != domain.EventCardMoved {
+		t.Errorf("got event type %s, want %s", event.Type, domain.EventCardMoved)
+	}
+	if event.BoardID != "board" {
+		t.Errorf("got board %s, want board", event.BoardID)
+	}
+	if event.Card != moved || event.Card.ColumnID != "done" {
+		t.Errorf("got card %+v, want the moved card in column done", event.Card)
+	}
+}
+
+func TestCardServiceMoveFailurePublishesNothing(t *testing.T) {
+	tests := []struct {
+		name     string
+		boardID  string
+		version  int32
+		wantCode string
+	}{
+		{"stale version", "board", 0, boards.ECONFLICT},
+		{"card on another board", "other", 1, boards.ENOTFOUND},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := native.NewEventPublisher()
+			cards := newCardService(publisher)
+
+			_, err := cards.Move("user", tt.boardID, "card", "done", "", tt.version)
+			if code := boards.ErrorCode(err); code != tt.wantCode {
+				t.Fatalf("got code %q, want %q", code, tt.wantCode)
+			}
+			if published := publisher.Published(); len(published) != 0 {
+				t.Errorf("got %d events, want none", len(published))
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type CardStore interface {
+	// Insert stores the card after the last card of its column.
+	Insert(*domain.Card) (*domain.Card, error)
+	// Update fails with boards.ECONFLICT when card.Version is not the stored version.
+	Update(*domain.Card) (*domain.Card, error)
+	Remove(cardID string) error
+	Get(cardID string) (*domain.Card, error)
+	ListByBoard(boardID string) ([]*domain.Card, error)
+	// Move places the card in toColumnID right before beforeCardID, or last when beforeCardID is
+	// empty. Column and rank change atomically, and a stale version fails with boards.ECONFLICT.
+	Move(cardID, toColumnID, beforeCardID string, version int32) (*domain.Card, error)
+}
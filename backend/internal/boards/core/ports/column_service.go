@@ -0,0 +1,120 @@
+package ports
+
+import (
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+)
+
+type ColumnService struct {
+	store     ColumnStore
+	publisher EventPublisher
+}
+
+func NewColumnService(store ColumnStore, publisher EventPublisher) *ColumnService {
+	return &ColumnService{store, publisher}
+}
+
+func (s *ColumnService) Create(column *domain.Column) (*domain.Column, error) {
+	op := "ports.ColumnService.Create"
+
+	storedColumn, err := s.store.Insert(column)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	s.publisher.Publish(domain.NewColumnEvent(domain.EventColumnCreated, storedColumn))
+
+	return storedColumn, nil
+}
+
+// Rename retitles the column if version is the stored version, failing with ECONFLICT when it
+// is stale.
+func (s *ColumnService) Rename(boardID, columnID, title string, version int32) (*domain.Column, error) {
+	op := "ports.ColumnService.Rename"
+
+	column, err := s.Find(boardID, columnID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	column.Title = title
+	column.Version = version
+
+	updatedColumn, err := s.store.Update(column)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	s.publisher.Publish(domain.NewColumnEvent(domain.EventColumnRenamed, updatedColumn))
+
+	return updatedColumn, nil
+}
+
+func (s *ColumnService) Delete(boardID, columnID string) error {
+	op := "ports.ColumnService.Delete"
+
+	column, err := s.Find(boardID, columnID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	err = s.store.Remove(columnID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+	s.publisher.Publish(domain.NewColumnEvent(domain.EventColumnDeleted, column))
+
+	return nil
+}
+
+func (s *ColumnService) Move(boardID, columnID, beforeColumnID string) (*domain.Column, error) {
+	op := "ports.ColumnService.Move"
+
+	if columnID == beforeColumnID {
+		return nil, &boards.Error{Op: op, Code: boards.EINVALID, Message: "column cannot be moved before itself"}
+	}
+
+	_, err := s.Find(boardID, columnID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	if beforeColumnID != "" {
+		_, err = s.Find(boardID, beforeColumnID)
+		if err != nil {
+			return nil, &boards.Error{Op: op, Err: err}
+		}
+	}
+
+	movedColumn, err := s.store.Move(columnID, beforeColumnID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	s.publisher.Publish(domain.NewColumnEvent(domain.EventColumnMoved, movedColumn))
+
+	return movedColumn, nil
+}
+
+// Find returns the column only if it belongs to the given board.
+func (s *ColumnService) Find(boardID, columnID string) (*domain.Column, error) {
+	op := "ports.ColumnService.Find"
+
+	column, err := s.store.Get(columnID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	if column.BoardID != boardID {
+		return nil, &boards.Error{Op: op, Code: boards.ENOTFOUND, Message: fmt.Sprintf("column (%s) does not exist on board (%s)", columnID, boardID)}
+	}
+
+	return column, nil
+}
+
+func (s *ColumnService) ListByBoard(boardID string) ([]*domain.Column, error) {
+	op := "ports.ColumnService.ListByBoard"
+
+	columns, err := s.store.ListByBoard(boardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return columns, nil
+}
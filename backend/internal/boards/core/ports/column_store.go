@@ -0,0 +1,14 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type ColumnStore interface {
+	// Insert stores the column after the last column of its board.
+	Insert(*domain.Column) (*domain.Column, error)
+	Update(*domain.Column) (*domain.Column, error)
+	Remove(columnID string) error
+	Get(columnID string) (*domain.Column, error)
+	ListByBoard(boardID string) ([]*domain.Column, error)
+	// Move re-ranks the column right before beforeColumnID, or last when beforeColumnID is empty.
+	Move(columnID, beforeColumnID string) (*domain.Column, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type EventLog interface {
+	// Since returns the events of the board published after lastEventID, oldest first. The log
+	// only keeps the latest events of every board; when some of the events after lastEventID were
+	// already discarded it fails with boards.ENOTFOUND, and the client has to reload the board.
+	Since(boardID, lastEventID string) ([]*domain.Event, error)
+}
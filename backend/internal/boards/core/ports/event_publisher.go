@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type EventPublisher interface {
+	// Publish is best effort: the change the event describes is already stored, so a failed
+	// delivery must not fail the request that made it.
+	Publish(*domain.Event)
+}
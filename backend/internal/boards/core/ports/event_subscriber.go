@@ -0,0 +1,14 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type EventSubscriber interface {
+	Subscribe(boardID string) Subscription
+}
+
+type Subscription interface {
+	// Events is closed once the subscription ends, either through Close or because the
+	// subscriber fell too far behind and was dropped.
+	Events() <-chan *domain.Event
+	Close()
+}
@@ -0,0 +1,177 @@
+package ports
+
+import (
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	userPorts "github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+)
+
+type MemberService struct {
+	store           MemberStore
+	credentialStore userPorts.CredentialStore
+	// requireVerifiedInvitee keeps accounts that have not verified their address off boards.
+	requireVerifiedInvitee bool
+}
+
+func NewMemberService(store MemberStore, credentialStore userPorts.CredentialStore, requireVerifiedInvitee bool) *MemberService {
+	return &MemberService{store, credentialStore, requireVerifiedInvitee}
+}
+
+func (s *MemberService) Authorize(boardID, userID string, role domain.Role) (*domain.Member, error) {
+	op := "ports.MemberService.Authorize"
+
+	member, err := s.store.Get(boardID, userID)
+	if err != nil {
+		if boards.ErrorCode(err) == boards.ENOTFOUND {
+			return nil, &boards.Error{Op: op, Code: boards.ENOTFOUND, Message: "board does not exist", Err: err}
+		}
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	if !member.Role.Includes(role) {
+		return nil, &boards.Error{Op: op, Code: boards.EFORBIDDEN, Message: fmt.Sprintf("%s role required", role)}
+	}
+
+	return member, nil
+}
+
+// Invite adds the account registered with email to the board. actor is the membership of the
+// user sending the invitation.
+func (s *MemberService) Invite(actor *domain.Member, email string, role domain.Role) (*domain.Member, error) {
+	op := "ports.MemberService.Invite"
+
+	err := s.checkGrant(actor, role)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	credential, err := s.credentialStore.FindByEmail(email)
+	if err != nil {
+		if users.ErrorCode(err) == users.ENOTFOUND {
+			return nil, &boards.Error{Op: op, Code: boards.ENOTFOUND, Message: fmt.Sprintf("no user with email (%s)", email), Err: err}
+		}
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	if credential.DeletedAt != nil {
+		return nil, &boards.Error{Op: op, Code: boards.ENOTFOUND, Message: fmt.Sprintf("no user with email (%s)", email)}
+	}
+	if s.requireVerifiedInvitee && credential.EmailVerifiedAt == nil {
+		return nil, &boards.Error{Op: op, Code: boards.EINVALID, Message: fmt.Sprintf("user with email (%s) has not verified it yet", email)}
+	}
+
+	member, err := s.store.Insert(&domain.Member{
+		BoardID: actor.BoardID,
+		UserID:  credential.UserID,
+		Role:    role,
+	})
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return member, nil
+}
+
+func (s *MemberService) ChangeRole(actor *domain.Member, userID string, role domain.Role) (*domain.Member, error) {
+	op := "ports.MemberService.ChangeRole"
+
+	member, err := s.store.Get(actor.BoardID, userID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	err = s.checkGrant(actor, member.Role)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+	err = s.checkGrant(actor, role)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	if member.Role == domain.RoleOwner && role != domain.RoleOwner {
+		err = s.checkNotLastOwner(actor.BoardID)
+		if err != nil {
+			return nil, &boards.Error{Op: op, Err: err}
+		}
+	}
+
+	member.Role = role
+	updatedMember, err := s.store.UpdateRole(member)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return updatedMember, nil
+}
+
+// Remove takes userID off the board. Members may always remove themselves; removing anyone else
+// needs a role that could have granted theirs.
+func (s *MemberService) Remove(actor *domain.Member, userID string) error {
+	op := "ports.MemberService.Remove"
+
+	member, err := s.store.Get(actor.BoardID, userID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	if member.UserID != actor.UserID {
+		err = s.checkGrant(actor, member.Role)
+		if err != nil {
+			return &boards.Error{Op: op, Err: err}
+		}
+	}
+
+	if member.Role == domain.RoleOwner {
+		err = s.checkNotLastOwner(actor.BoardID)
+		if err != nil {
+			return &boards.Error{Op: op, Err: err}
+		}
+	}
+
+	err = s.store.Remove(actor.BoardID, userID)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+func (s *MemberService) ListByBoard(boardID string) ([]*domain.Member, error) {
+	op := "ports.MemberService.ListByBoard"
+
+	members, err := s.store.ListByBoard(boardID)
+	if err != nil {
+		return nil, &boards.Error{Op: op, Err: err}
+	}
+
+	return members, nil
+}
+
+// checkGrant makes sure actor may hand out (or take away) role. Admins manage everyone but
+// owners, and only owners manage owners.
+func (s *MemberService) checkGrant(actor *domain.Member, role domain.Role) error {
+	op := "ports.MemberService.checkGrant"
+
+	if !role.Valid() {
+		return &boards.Error{Op: op, Code: boards.EINVALID, Message: fmt.Sprintf("unknown role (%s)", role)}
+	}
+	if !actor.Role.Includes(domain.RoleAdmin) || !actor.Role.Includes(role) {
+		return &boards.Error{Op: op, Code: boards.EFORBIDDEN, Message: fmt.Sprintf("%s cannot manage %s members", actor.Role, role)}
+	}
+	return nil
+}
+
+func (s *MemberService) checkNotLastOwner(boardID string) error {
+	op := "ports.MemberService.checkNotLastOwner"
+
+	owners, err := s.store.CountByRole(boardID, domain.RoleOwner)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+	if owners <= 1 {
+		return &boards.Error{Op: op, Code: boards.ECONFLICT, Message: "a board needs at least one owner"}
+	}
+	return nil
+}
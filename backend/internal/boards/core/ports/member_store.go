@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+
+type MemberStore interface {
+	// Insert fails with boards.ECONFLICT when the user already is a member of the board.
+	Insert(*domain.Member) (*domain.Member, error)
+	UpdateRole(*domain.Member) (*domain.Member, error)
+	Remove(boardID, userID string) error
+	Get(boardID, userID string) (*domain.Member, error)
+	ListByBoard(boardID string) ([]*domain.Member, error)
+	CountByRole(boardID string, role domain.Role) (int, error)
+}
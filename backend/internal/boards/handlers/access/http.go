@@ -0,0 +1,55 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type MemberKey struct {
+}
+
+type Handler struct {
+	authorizer ports.Authorizer
+	log        logging.Logger
+}
+
+func NewAccessHandler(authorizer ports.Authorizer, log logging.Logger) *Handler {
+	return &Handler{authorizer, log}
+}
+
+// Require lets a request through only when the logged-in user has at least role on the board in
+// the route. It must run after auth.Handler.AuthMiddleware; the caller's membership is stored in
+// the request context under MemberKey.
+func (h *Handler) Require(role domain.Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+		boardID := mux.Vars(r)["board_id"]
+
+		member, err := h.authorizer.Authorize(boardID, loggedInUserID, role)
+		if err != nil {
+			switch boards.ErrorCode(err) {
+			case boards.EINVALID, boards.ENOTFOUND:
+				h.log.Debug(err.Error())
+				http.Error(rw, "board does not exist", http.StatusNotFound)
+			case boards.EFORBIDDEN:
+				h.log.Debug(fmt.Sprintf("user %s cannot access board %s. err: %s", loggedInUserID, boardID, err.Error()))
+				http.Error(rw, boards.ErrorMessage(err), http.StatusForbidden)
+			default:
+				h.log.Warn(err.Error())
+				http.Error(rw, boards.ErrorMessage(err), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), &MemberKey{}, member)
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,180 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	"github.com/hardiksachan/kanban_board/backend/shared/etag"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	board    *ports.BoardService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewBoardHandler(board *ports.BoardService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{board, log, validator}
+}
+
+func (h *Handler) Create(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	rm, err := jsonHelper.Parse[CreateRequest](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return
+	}
+
+	board, err := h.board.Create(rm.toDomain(loggedInUserID))
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("board created successfully. boardId: %s", board.BoardID))
+	rw.WriteHeader(http.StatusCreated)
+	h.writeJSON(rw, toBoardResponse(board))
+}
+
+func (h *Handler) List(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	memberBoards, err := h.board.ListByMember(loggedInUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	response := make([]*BoardResponse, 0, len(memberBoards))
+	for _, board := range memberBoards {
+		response = append(response, toBoardResponse(board))
+	}
+
+	h.log.Debug(fmt.Sprintf("boards fetch succesfull. userId: %s", loggedInUserID))
+	h.writeJSON(rw, response)
+}
+
+func (h *Handler) Get(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	board, err := h.board.Find(member.BoardID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("board fetch succesfull. boardId: %s", board.BoardID))
+	rw.Header().Set("ETag", etag.Format(board.Version))
+	h.writeJSON(rw, toBoardResponse(board))
+}
+
+func (h *Handler) Update(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	rm, err := jsonHelper.Parse[UpdateRequest](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return
+	}
+
+	version, ok := etag.RequireIfMatch(rw, r)
+	if !ok {
+		return
+	}
+
+	updatedBoard, err := h.board.Update(member.UserID, &domain.Board{
+		BoardID:     member.BoardID,
+		Title:       rm.Title,
+		Description: rm.Description,
+		Version:     version,
+	})
+	if err != nil {
+		h.writePreconditionError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("board update succesfull. boardId: %s", updatedBoard.BoardID))
+	rw.Header().Set("ETag", etag.Format(updatedBoard.Version))
+	h.writeJSON(rw, toBoardResponse(updatedBoard))
+}
+
+func (h *Handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	err := h.board.Delete(member.UserID, member.BoardID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("board delete succesfull. boardId: %s", member.BoardID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// writePreconditionError reports a stale If-Match as 412 rather than 409.
+func (h *Handler) writePreconditionError(rw http.ResponseWriter, err error) {
+	if boards.ErrorCode(err) == boards.ECONFLICT {
+		h.log.Debug(err.Error())
+		etag.WritePreconditionFailed(rw, boards.ErrorMessage(err))
+		return
+	}
+	h.writeError(rw, err)
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch boards.ErrorCode(err) {
+	case boards.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusBadRequest)
+	case boards.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusNotFound)
+	case boards.EFORBIDDEN:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusForbidden)
+	case boards.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusConflict)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
@@ -0,0 +1,46 @@
+package board
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"time"
+)
+
+type CreateRequest struct {
+	Title       string `json:"title" validate:"required,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+}
+
+type UpdateRequest struct {
+	Title       string `json:"title" validate:"required,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+}
+
+type BoardResponse struct {
+	BoardID     string    `json:"board_id"`
+	OwnerID     string    `json:"owner_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Version     int32     `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	ModifiedAt  time.Time `json:"modified_at"`
+}
+
+func (r *CreateRequest) toDomain(ownerID string) *domain.Board {
+	return &domain.Board{
+		OwnerID:     ownerID,
+		Title:       r.Title,
+		Description: r.Description,
+	}
+}
+
+func toBoardResponse(board *domain.Board) *BoardResponse {
+	return &BoardResponse{
+		BoardID:     board.BoardID,
+		OwnerID:     board.OwnerID,
+		Title:       board.Title,
+		Description: board.Description,
+		Version:     board.Version,
+		CreatedAt:   board.CreatedAt,
+		ModifiedAt:  board.ModifiedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package card
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	"github.com/hardiksachan/kanban_board/backend/shared/etag"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	card     *ports.CardService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewCardHandler(card *ports.CardService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{card, log, validator}
+}
+
+func (h *Handler) List(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	cards, err := h.card.ListByBoard(boardID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	response := make([]*CardResponse, 0, len(cards))
+	for _, card := range cards {
+		response = append(response, ToCardResponse(card))
+	}
+
+	h.log.Debug(fmt.Sprintf("cards fetch succesfull. boardId: %s", boardID))
+	h.writeJSON(rw, response)
+}
+
+func (h *Handler) Get(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	card, err := h.card.Find(boardID, mux.Vars(r)["card_id"])
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("card fetch succesfull. cardId: %s", card.CardID))
+	rw.Header().Set("ETag", etag.Format(card.Version))
+	h.writeJSON(rw, ToCardResponse(card))
+}
+
+func (h *Handler) Create(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	rm, ok := parseRequest[CreateRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	card, err := h.card.Create(member.UserID, rm.toDomain(member.BoardID, mux.Vars(r)["column_id"]))
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("card created successfully. cardId: %s", card.CardID))
+	rw.WriteHeader(http.StatusCreated)
+	h.writeJSON(rw, ToCardResponse(card))
+}
+
+func (h *Handler) Update(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	rm, ok := parseRequest[UpdateRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	version, ok := etag.RequireIfMatch(rw, r)
+	if !ok {
+		return
+	}
+
+	card, err := h.card.Update(member.UserID, rm.toDomain(member.BoardID, mux.Vars(r)["card_id"], version))
+	if err != nil {
+		h.writePreconditionError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("card update succesfull. cardId: %s, version: %d", card.CardID, card.Version))
+	rw.Header().Set("ETag", etag.Format(card.Version))
+	h.writeJSON(rw, ToCardResponse(card))
+}
+
+func (h *Handler) Move(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	rm, ok := parseRequest[MoveRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	version, ok := etag.RequireIfMatch(rw, r)
+	if !ok {
+		return
+	}
+
+	card, err := h.card.Move(member.UserID, member.BoardID, mux.Vars(r)["card_id"], rm.ColumnID, rm.BeforeCardID, version)
+	if err != nil {
+		h.writePreconditionError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("card move succesfull. cardId: %s, columnId: %s, rank: %s", card.CardID, card.ColumnID, card.Rank))
+	rw.Header().Set("ETag", etag.Format(card.Version))
+	h.writeJSON(rw, ToCardResponse(card))
+}
+
+func (h *Handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	cardID := mux.Vars(r)["card_id"]
+	err := h.card.Delete(member.UserID, member.BoardID, cardID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("card delete succesfull. cardId: %s", cardID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+// writePreconditionError reports a stale If-Match as 412 rather than 409.
+func (h *Handler) writePreconditionError(rw http.ResponseWriter, err error) {
+	if boards.ErrorCode(err) == boards.ECONFLICT {
+		h.log.Debug(err.Error())
+		etag.WritePreconditionFailed(rw, boards.ErrorMessage(err))
+		return
+	}
+	h.writeError(rw, err)
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch boards.ErrorCode(err) {
+	case boards.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusBadRequest)
+	case boards.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusNotFound)
+	case boards.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusConflict)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
@@ -0,0 +1,79 @@
+package card
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"time"
+)
+
+type CreateRequest struct {
+	Title       string     `json:"title" validate:"required,min=1,max=200"`
+	Description string     `json:"description,omitempty" validate:"max=10000"`
+	AssigneeIDs []string   `json:"assignee_ids,omitempty" validate:"dive,uuid"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+type UpdateRequest struct {
+	Title       string     `json:"title" validate:"required,min=1,max=200"`
+	Description string     `json:"description,omitempty" validate:"max=10000"`
+	AssigneeIDs []string   `json:"assignee_ids,omitempty" validate:"dive,uuid"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+type MoveRequest struct {
+	ColumnID string `json:"column_id" validate:"required,uuid"`
+	// BeforeCardID is the card to place the moved card in front of. Empty moves it to the end of the column.
+	BeforeCardID string `json:"before_card_id,omitempty" validate:"omitempty,uuid"`
+}
+
+type CardResponse struct {
+	CardID      string     `json:"card_id"`
+	BoardID     string     `json:"board_id"`
+	ColumnID    string     `json:"column_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	AssigneeIDs []string   `json:"assignee_ids"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Rank        string     `json:"rank"`
+	Version     int32      `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ModifiedAt  time.Time  `json:"modified_at"`
+}
+
+func (r *CreateRequest) toDomain(boardID, columnID string) *domain.Card {
+	return &domain.Card{
+		BoardID:     boardID,
+		ColumnID:    columnID,
+		Title:       r.Title,
+		Description: r.Description,
+		AssigneeIDs: r.AssigneeIDs,
+		DueDate:     r.DueDate,
+	}
+}
+
+func (r *UpdateRequest) toDomain(boardID, cardID string, version int32) *domain.Card {
+	return &domain.Card{
+		CardID:      cardID,
+		BoardID:     boardID,
+		Title:       r.Title,
+		Description: r.Description,
+		AssigneeIDs: r.AssigneeIDs,
+		DueDate:     r.DueDate,
+		Version:     version,
+	}
+}
+
+func ToCardResponse(card *domain.Card) *CardResponse {
+	return &CardResponse{
+		CardID:      card.CardID,
+		BoardID:     card.BoardID,
+		ColumnID:    card.ColumnID,
+		Title:       card.Title,
+		Description: card.Description,
+		AssigneeIDs: card.AssigneeIDs,
+		DueDate:     card.DueDate,
+		Rank:        card.Rank,
+		Version:     card.Version,
+		CreatedAt:   card.CreatedAt,
+		ModifiedAt:  card.ModifiedAt,
+	}
+}
@@ -0,0 +1,177 @@
+package column
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	"github.com/hardiksachan/kanban_board/backend/shared/etag"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	column   *ports.ColumnService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewColumnHandler(column *ports.ColumnService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{column, log, validator}
+}
+
+func (h *Handler) List(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	columns, err := h.column.ListByBoard(boardID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	response := make([]*ColumnResponse, 0, len(columns))
+	for _, column := range columns {
+		response = append(response, ToColumnResponse(column))
+	}
+
+	h.log.Debug(fmt.Sprintf("columns fetch succesfull. boardId: %s", boardID))
+	h.writeJSON(rw, response)
+}
+
+func (h *Handler) Create(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	rm, ok := parseRequest[CreateRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	column, err := h.column.Create(&domain.Column{
+		BoardID: boardID,
+		Title:   rm.Title,
+	})
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("column created successfully. columnId: %s", column.ColumnID))
+	rw.WriteHeader(http.StatusCreated)
+	h.writeJSON(rw, ToColumnResponse(column))
+}
+
+func (h *Handler) Rename(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	rm, ok := parseRequest[RenameRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	version, ok := etag.RequireIfMatch(rw, r)
+	if !ok {
+		return
+	}
+
+	column, err := h.column.Rename(boardID, mux.Vars(r)["column_id"], rm.Title, version)
+	if err != nil {
+		h.writePreconditionError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("column rename succesfull. columnId: %s", column.ColumnID))
+	rw.Header().Set("ETag", etag.Format(column.Version))
+	h.writeJSON(rw, ToColumnResponse(column))
+}
+
+func (h *Handler) Move(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	rm, ok := parseRequest[MoveRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	column, err := h.column.Move(boardID, mux.Vars(r)["column_id"], rm.BeforeColumnID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("column move succesfull. columnId: %s, rank: %s", column.ColumnID, column.Rank))
+	h.writeJSON(rw, ToColumnResponse(column))
+}
+
+func (h *Handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	boardID := r.Context().Value(&access.MemberKey{}).(*domain.Member).BoardID
+
+	columnID := mux.Vars(r)["column_id"]
+	err := h.column.Delete(boardID, columnID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("column delete succesfull. columnId: %s", columnID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+// writePreconditionError reports a stale If-Match as 412 rather than 409.
+func (h *Handler) writePreconditionError(rw http.ResponseWriter, err error) {
+	if boards.ErrorCode(err) == boards.ECONFLICT {
+		h.log.Debug(err.Error())
+		etag.WritePreconditionFailed(rw, boards.ErrorMessage(err))
+		return
+	}
+	h.writeError(rw, err)
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch boards.ErrorCode(err) {
+	case boards.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusBadRequest)
+	case boards.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusNotFound)
+	case boards.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusConflict)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
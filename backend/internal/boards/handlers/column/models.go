@@ -0,0 +1,41 @@
+package column
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"time"
+)
+
+type CreateRequest struct {
+	Title string `json:"title" validate:"required,min=1,max=100"`
+}
+
+type RenameRequest struct {
+	Title string `json:"title" validate:"required,min=1,max=100"`
+}
+
+type MoveRequest struct {
+	// BeforeColumnID is the column to place the moved column in front of. Empty moves it to the end.
+	BeforeColumnID string `json:"before_column_id,omitempty" validate:"omitempty,uuid"`
+}
+
+type ColumnResponse struct {
+	ColumnID   string    `json:"column_id"`
+	BoardID    string    `json:"board_id"`
+	Title      string    `json:"title"`
+	Rank       string    `json:"rank"`
+	Version    int32     `json:"version"`
+	CreatedAt  time.Time `json:"created_at"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+func ToColumnResponse(column *domain.Column) *ColumnResponse {
+	return &ColumnResponse{
+		ColumnID:   column.ColumnID,
+		BoardID:    column.BoardID,
+		Title:      column.Title,
+		Rank:       column.Rank,
+		Version:    column.Version,
+		CreatedAt:  column.CreatedAt,
+		ModifiedAt: column.ModifiedAt,
+	}
+}
@@ -0,0 +1,112 @@
+package events
+
+import (
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+	"time"
+)
+
+const (
+	// writeWait bounds every write, so a stuck connection cannot hold on to its goroutine.
+	writeWait = 10 * time.Second
+	// pongWait is how long the client may stay silent before the connection is considered dead.
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 9 / 10
+	// closeTooSlow is sent to clients the hub dropped because they did not keep up.
+	closeTooSlow = 4000
+)
+
+type Handler struct {
+	subscriber ports.EventSubscriber
+	eventLog   ports.EventLog
+	log        logging.Logger
+	upgrader   websocket.Upgrader
+}
+
+func NewEventsHandler(subscriber ports.EventSubscriber, eventLog ports.EventLog, log logging.Logger) *Handler {
+	return &Handler{subscriber, eventLog, log, websocket.Upgrader{}}
+}
+
+// Stream upgrades the request to a WebSocket and pushes every event of the board to it until
+// either side goes away. Messages sent by the client are ignored.
+func (h *Handler) Stream(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	conn, err := h.upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		h.log.Debug(fmt.Sprintf("unable to upgrade connection. err: %s", err.Error()))
+		return
+	}
+	defer conn.Close()
+
+	sub := h.subscriber.Subscribe(member.BoardID)
+	defer sub.Close()
+
+	h.log.Debug(fmt.Sprintf("event stream opened. boardId: %s, userId: %s", member.BoardID, member.UserID))
+
+	gone := make(chan struct{})
+	go h.discardIncoming(conn, gone)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				h.log.Debug(fmt.Sprintf("dropping slow event stream. boardId: %s, userId: %s", member.BoardID, member.UserID))
+				h.close(conn, closeTooSlow, "too slow, reconnect and reload the board")
+				return
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			err = conn.WriteJSON(toEventResponse(event))
+			if err != nil {
+				h.log.Debug(fmt.Sprintf("unable to write event. err: %s", err.Error()))
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			err = conn.WriteMessage(websocket.PingMessage, nil)
+			if err != nil {
+				h.log.Debug(fmt.Sprintf("unable to ping. err: %s", err.Error()))
+				return
+			}
+		case <-gone:
+			h.log.Debug(fmt.Sprintf("event stream closed. boardId: %s, userId: %s", member.BoardID, member.UserID))
+			return
+		}
+	}
+}
+
+// discardIncoming reads the connection so that pongs and close frames get processed, and closes
+// gone once the client disconnects or stops answering pings.
+func (h *Handler) discardIncoming(conn *websocket.Conn, gone chan<- struct{}) {
+	defer close(gone)
+
+	conn.SetReadLimit(512)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, _, err := conn.NextReader()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (h *Handler) close(conn *websocket.Conn, code int, reason string) {
+	err := conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to send close frame. err: %s", err.Error()))
+	}
+}
@@ -0,0 +1,33 @@
+package events
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/card"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/column"
+	"time"
+)
+
+type EventResponse struct {
+	ID         string                 `json:"id,omitempty"`
+	Type       domain.EventType       `json:"type"`
+	BoardID    string                 `json:"board_id"`
+	Column     *column.ColumnResponse `json:"column,omitempty"`
+	Card       *card.CardResponse     `json:"card,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+func toEventResponse(event *domain.Event) *EventResponse {
+	response := &EventResponse{
+		ID:         event.ID,
+		Type:       event.Type,
+		BoardID:    event.BoardID,
+		OccurredAt: event.OccurredAt,
+	}
+	if event.Column != nil {
+		response.Column = column.ToColumnResponse(event.Column)
+	}
+	if event.Card != nil {
+		response.Card = card.ToCardResponse(event.Card)
+	}
+	return response
+}
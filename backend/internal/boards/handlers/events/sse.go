@@ -0,0 +1,145 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	"net/http"
+	"time"
+)
+
+const (
+	// keepAlivePeriod is how often an idle stream gets a comment line, so proxies do not time it out.
+	keepAlivePeriod = 30 * time.Second
+	// retryAfter tells EventSource how long to wait before reconnecting, in milliseconds.
+	retryAfter = 2000
+)
+
+// ServerSentEvents streams the events of the board as text/event-stream, for clients that cannot
+// keep a WebSocket open. A client reconnecting with Last-Event-ID first gets the events it missed;
+// if those are no longer logged it gets a "reset" event instead and should reload the board.
+func (h *Handler) ServerSentEvents(rw http.ResponseWriter, r *http.Request) {
+	member := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		h.log.Warn("response writer does not support streaming")
+
+		http.Error(rw, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// subscribe before reading the log, so no event falls between the two
+	sub := h.subscriber.Subscribe(member.BoardID)
+	defer sub.Close()
+
+	var missed []*domain.Event
+	reset := false
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		var err error
+		missed, err = h.eventLog.Since(member.BoardID, lastEventID)
+		if boards.ErrorCode(err) == boards.ENOTFOUND {
+			h.log.Debug(fmt.Sprintf("unable to resume event stream. err: %s", err.Error()))
+			reset = true
+		} else if err != nil {
+			h.writeError(rw, err)
+			return
+		}
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	// keep nginx from buffering the stream
+	rw.Header().Set("X-Accel-Buffering", "no")
+	rw.WriteHeader(http.StatusOK)
+
+	h.log.Debug(fmt.Sprintf("event stream opened. boardId: %s, userId: %s, missed: %d", member.BoardID, member.UserID, len(missed)))
+
+	_, err := fmt.Fprintf(rw, "retry: %d\n\n", retryAfter)
+	if err != nil {
+		return
+	}
+	if reset {
+		_, err = fmt.Fprint(rw, "event: reset\ndata: {}\n\n")
+		if err != nil {
+			return
+		}
+	}
+
+	// missed events may also arrive through the subscription, they are only sent once
+	replayed := make(map[string]struct{}, len(missed))
+	for _, event := range missed {
+		err = h.writeSSE(rw, event)
+		if err != nil {
+			return
+		}
+		replayed[event.ID] = struct{}{}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAlivePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				// the client reconnects on its own and catches up through Last-Event-ID
+				h.log.Debug(fmt.Sprintf("dropping slow event stream. boardId: %s, userId: %s", member.BoardID, member.UserID))
+				return
+			}
+			if _, ok := replayed[event.ID]; ok {
+				delete(replayed, event.ID)
+				continue
+			}
+
+			err = h.writeSSE(rw, event)
+			if err != nil {
+				h.log.Debug(fmt.Sprintf("unable to write event. err: %s", err.Error()))
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			_, err = fmt.Fprint(rw, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			h.log.Debug(fmt.Sprintf("event stream closed. boardId: %s, userId: %s", member.BoardID, member.UserID))
+			return
+		}
+	}
+}
+
+func (h *Handler) writeSSE(rw http.ResponseWriter, event *domain.Event) error {
+	data, err := json.Marshal(toEventResponse(event))
+	if err != nil {
+		return err
+	}
+
+	if event.ID != "" {
+		_, err = fmt.Fprintf(rw, "id: %s\n", event.ID)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(rw, "data: %s\n\n", data)
+	return err
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch boards.ErrorCode(err) {
+	case boards.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusBadRequest)
+	case boards.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusNotFound)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
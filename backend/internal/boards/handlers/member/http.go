@@ -0,0 +1,142 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/handlers/access"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	member   *ports.MemberService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewMemberHandler(member *ports.MemberService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{member, log, validator}
+}
+
+func (h *Handler) List(rw http.ResponseWriter, r *http.Request) {
+	actor := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	members, err := h.member.ListByBoard(actor.BoardID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	response := make([]*MemberResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, toMemberResponse(member))
+	}
+
+	h.log.Debug(fmt.Sprintf("members fetch succesfull. boardId: %s", actor.BoardID))
+	h.writeJSON(rw, response)
+}
+
+func (h *Handler) Invite(rw http.ResponseWriter, r *http.Request) {
+	actor := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	rm, ok := parseRequest[InviteRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	member, err := h.member.Invite(actor, rm.Email, domain.Role(rm.Role))
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("member invited successfully. boardId: %s, userId: %s", member.BoardID, member.UserID))
+	rw.WriteHeader(http.StatusCreated)
+	h.writeJSON(rw, toMemberResponse(member))
+}
+
+func (h *Handler) ChangeRole(rw http.ResponseWriter, r *http.Request) {
+	actor := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+
+	rm, ok := parseRequest[ChangeRoleRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	member, err := h.member.ChangeRole(actor, mux.Vars(r)["user_id"], domain.Role(rm.Role))
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("member role change succesfull. boardId: %s, userId: %s, role: %s", member.BoardID, member.UserID, member.Role))
+	h.writeJSON(rw, toMemberResponse(member))
+}
+
+func (h *Handler) Remove(rw http.ResponseWriter, r *http.Request) {
+	actor := r.Context().Value(&access.MemberKey{}).(*domain.Member)
+	userID := mux.Vars(r)["user_id"]
+
+	err := h.member.Remove(actor, userID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("member remove succesfull. boardId: %s, userId: %s", actor.BoardID, userID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch boards.ErrorCode(err) {
+	case boards.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusBadRequest)
+	case boards.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusNotFound)
+	case boards.EFORBIDDEN:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusForbidden)
+	case boards.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusConflict)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, boards.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
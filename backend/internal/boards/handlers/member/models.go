@@ -0,0 +1,35 @@
+package member
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"time"
+)
+
+type InviteRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=owner admin editor viewer"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner admin editor viewer"`
+}
+
+type MemberResponse struct {
+	BoardID   string    `json:"board_id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toMemberResponse(member *domain.Member) *MemberResponse {
+	return &MemberResponse{
+		BoardID:   member.BoardID,
+		UserID:    member.UserID,
+		Name:      member.Name,
+		Email:     member.Email,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt,
+	}
+}
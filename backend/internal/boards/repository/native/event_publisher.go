@@ -0,0 +1,34 @@
+package native
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"sync"
+)
+
+// EventPublisher keeps every published event in memory instead of delivering it, so tests can
+// assert on what a service published.
+type EventPublisher struct {
+	mu     sync.Mutex
+	events []*domain.Event
+}
+
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{}
+}
+
+func (p *EventPublisher) Publish(event *domain.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+}
+
+// Published returns the events published so far, oldest first.
+func (p *EventPublisher) Published() []*domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	published := make([]*domain.Event, len(p.events))
+	copy(published, p.events)
+	return published
+}
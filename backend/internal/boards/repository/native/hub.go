@@ -0,0 +1,88 @@
+package native
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"sync"
+)
+
+// Hub fans events out to the subscribers of each board, in process. Every subscriber gets a
+// buffer of events; one that lets it fill up is dropped instead of slowing down publishers and
+// the other subscribers of the board.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*subscription]struct{}
+	buffer      int
+}
+
+func NewHub(buffer int) *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[*subscription]struct{}),
+		buffer:      buffer,
+	}
+}
+
+func (h *Hub) Publish(event *domain.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[event.BoardID] {
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *Hub) Subscribe(boardID string) ports.Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &subscription{
+		hub:     h,
+		boardID: boardID,
+		events:  make(chan *domain.Event, h.buffer),
+	}
+
+	if h.subscribers[boardID] == nil {
+		h.subscribers[boardID] = make(map[*subscription]struct{})
+	}
+	h.subscribers[boardID][sub] = struct{}{}
+
+	return sub
+}
+
+// remove must be called with h.mu held. Removing a subscription twice is a no-op.
+func (h *Hub) remove(sub *subscription) {
+	boardSubscribers, ok := h.subscribers[sub.boardID]
+	if !ok {
+		return
+	}
+	if _, ok := boardSubscribers[sub]; !ok {
+		return
+	}
+
+	delete(boardSubscribers, sub)
+	if len(boardSubscribers) == 0 {
+		delete(h.subscribers, sub.boardID)
+	}
+	close(sub.events)
+}
+
+type subscription struct {
+	hub     *Hub
+	boardID string
+	events  chan *domain.Event
+}
+
+func (s *subscription) Events() <-chan *domain.Event {
+	return s.events
+}
+
+func (s *subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
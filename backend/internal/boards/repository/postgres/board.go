@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres/board/dao"
+	"github.com/hardiksachan/kanban_board/backend/shared"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type BoardStore struct {
+	db *pgxpool.Pool
+	q  *dao.Queries
+}
+
+func NewBoardStore(db *pgxpool.Pool) *BoardStore {
+	return &BoardStore{db, dao.New(db)}
+}
+
+func (s *BoardStore) Insert(board *domain.Board) (*domain.Board, error) {
+	op := "postgres.BoardStore.Insert"
+
+	ownerUuid, err := parseUUID(op, board.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the creator becomes the board's first owner in the same transaction, so a board is never
+	// left without anyone who can manage it
+	var dbBoard dao.Board
+	err = inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		var err error
+		dbBoard, err = q.InsertBoard(ctx, dao.InsertBoardParams{
+			OwnerID:     *ownerUuid,
+			Title:       board.Title,
+			Description: board.Description,
+		})
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+
+		_, err = q.InsertMember(ctx, dao.InsertMemberParams{
+			BoardID: dbBoard.BoardID,
+			UserID:  dbBoard.OwnerID,
+			Role:    string(domain.RoleOwner),
+		})
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainBoard(dbBoard), nil
+}
+
+func (s *BoardStore) Update(board *domain.Board) (*domain.Board, error) {
+	op := "postgres.BoardStore.Update"
+
+	boardUuid, err := parseUUID(op, board.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbBoard, err := s.q.UpdateBoard(ctx, dao.UpdateBoardParams{
+		Title:       board.Title,
+		Description: board.Description,
+		BoardID:     *boardUuid,
+		Version:     board.Version,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, s.staleOrMissing(ctx, op, *boardUuid)
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainBoard(dbBoard), nil
+}
+
+func (s *BoardStore) Remove(boardID string) error {
+	op := "postgres.BoardStore.Remove"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = s.q.DeleteBoard(ctx, *boardUuid)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "board does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+func (s *BoardStore) Get(boardID string) (*domain.Board, error) {
+	op := "postgres.BoardStore.Get"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbBoard, err := s.q.GetBoard(ctx, *boardUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &boards.Error{Code: boards.ENOTFOUND, Message: "board does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainBoard(dbBoard), nil
+}
+
+func (s *BoardStore) ListByMember(userID string) ([]*domain.Board, error) {
+	op := "postgres.BoardStore.ListByMember"
+
+	userUuid, err := parseUUID(op, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbBoards, err := s.q.ListBoardsByMember(ctx, *userUuid)
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	result := make([]*domain.Board, 0, len(dbBoards))
+	for _, dbBoard := range dbBoards {
+		result = append(result, toDomainBoard(dbBoard))
+	}
+	return result, nil
+}
+
+// staleOrMissing explains why a versioned update touched no rows.
+func (s *BoardStore) staleOrMissing(ctx context.Context, op string, boardID uuid.UUID) error {
+	_, err := s.q.GetBoard(ctx, boardID)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "board does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return &boards.Error{Code: boards.ECONFLICT, Message: "board was changed by someone else, reload and try again", Op: op}
+}
+
+func toDomainBoard(dbBoard dao.Board) *domain.Board {
+	return &domain.Board{
+		BoardID:     dbBoard.BoardID.String(),
+		OwnerID:     dbBoard.OwnerID.String(),
+		Title:       dbBoard.Title,
+		Description: dbBoard.Description,
+		Version:     dbBoard.Version,
+		CreatedAt:   dbBoard.CreatedAt,
+		ModifiedAt:  dbBoard.ModifiedAt,
+	}
+}
+
+func parseUUID(op, id string) (*uuid.UUID, error) {
+	parsed, err := shared.GetUUIDFromString(id)
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINVALID, Message: fmt.Sprintf("Unable to parse UUID. id: %v", id), Op: op, Err: err}
+	}
+	return parsed, nil
+}
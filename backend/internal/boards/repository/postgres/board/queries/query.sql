@@ -0,0 +1,200 @@
+-- name: InsertBoard :one
+INSERT INTO board(owner_id, title, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetBoard :one
+SELECT *
+FROM board
+WHERE board_id = $1;
+
+-- name: ListBoardsByMember :many
+SELECT b.*
+FROM board b
+         JOIN board_member m ON m.board_id = b.board_id
+WHERE m.user_id = $1
+ORDER BY b.created_at;
+
+-- name: UpdateBoard :one
+UPDATE board
+SET title       = @title,
+    description = @description,
+    version     = version + 1,
+    modified_at = now()
+WHERE board_id = @board_id
+  AND version = @version::INTEGER
+RETURNING *;
+
+-- name: DeleteBoard :one
+DELETE
+FROM board
+WHERE board_id = $1
+RETURNING *;
+
+-- name: LockBoard :one
+SELECT board_id
+FROM board
+WHERE board_id = $1
+    FOR UPDATE;
+
+-- name: InsertColumn :one
+INSERT INTO board_column(board_id, title, rank)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetColumn :one
+SELECT *
+FROM board_column
+WHERE column_id = $1;
+
+-- name: ListColumnsByBoard :many
+SELECT *
+FROM board_column
+WHERE board_id = $1
+ORDER BY rank;
+
+-- name: LastColumnRank :one
+SELECT rank
+FROM board_column
+WHERE board_id = $1
+  AND column_id <> $2
+ORDER BY rank DESC
+LIMIT 1;
+
+-- name: PreviousColumnRank :one
+SELECT rank
+FROM board_column
+WHERE board_id = $1
+  AND rank < $2
+  AND column_id <> $3
+ORDER BY rank DESC
+LIMIT 1;
+
+-- name: UpdateColumnTitle :one
+UPDATE board_column
+SET title       = @title,
+    version     = version + 1,
+    modified_at = now()
+WHERE column_id = @column_id
+  AND version = @version::INTEGER
+RETURNING *;
+
+-- name: UpdateColumnRank :one
+UPDATE board_column
+SET rank        = $1,
+    version     = version + 1,
+    modified_at = now()
+WHERE column_id = $2
+RETURNING *;
+
+-- name: DeleteColumn :one
+DELETE
+FROM board_column
+WHERE column_id = $1
+RETURNING *;
+
+-- name: LockColumn :one
+SELECT board_id
+FROM board_column
+WHERE column_id = $1
+    FOR UPDATE;
+
+-- name: InsertCard :one
+INSERT INTO card(board_id, column_id, title, description, assignee_ids, due_date, rank)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetCard :one
+SELECT *
+FROM card
+WHERE card_id = $1;
+
+-- name: ListCardsByBoard :many
+SELECT *
+FROM card
+WHERE board_id = $1
+ORDER BY column_id, rank;
+
+-- name: LastCardRank :one
+SELECT rank
+FROM card
+WHERE column_id = $1
+  AND card_id <> $2
+ORDER BY rank DESC
+LIMIT 1;
+
+-- name: PreviousCardRank :one
+SELECT rank
+FROM card
+WHERE column_id = $1
+  AND rank < $2
+  AND card_id <> $3
+ORDER BY rank DESC
+LIMIT 1;
+
+-- name: UpdateCard :one
+UPDATE card
+SET title        = $1,
+    description  = $2,
+    assignee_ids = $3,
+    due_date     = $4,
+    version      = version + 1,
+    modified_at  = now()
+WHERE card_id = $5
+  AND version = $6
+RETURNING *;
+
+-- name: MoveCard :one
+UPDATE card
+SET column_id   = $1,
+    rank        = $2,
+    version     = version + 1,
+    modified_at = now()
+WHERE card_id = $3
+  AND version = $4
+RETURNING *;
+
+-- name: DeleteCard :one
+DELETE
+FROM card
+WHERE card_id = $1
+RETURNING *;
+
+-- name: InsertMember :one
+INSERT INTO board_member(board_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: GetMember :one
+SELECT *
+FROM board_member
+WHERE board_id = $1
+  AND user_id = $2;
+
+-- name: ListMembersByBoard :many
+SELECT m.board_id, m.user_id, m.role, m.created_at, u.name, u.email
+FROM board_member m
+         JOIN "user" u ON u.user_id = m.user_id
+WHERE m.board_id = $1
+ORDER BY m.created_at;
+
+-- name: CountMembersByRole :one
+SELECT COUNT(*)
+FROM board_member
+WHERE board_id = $1
+  AND role = $2;
+
+-- name: UpdateMemberRole :one
+UPDATE board_member
+SET role = $1
+WHERE board_id = $2
+  AND user_id = $3
+RETURNING *;
+
+-- name: DeleteMember :one
+DELETE
+FROM board_member
+WHERE board_id = $1
+  AND user_id = $2
+RETURNING *;
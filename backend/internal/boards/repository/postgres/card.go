@@ -0,0 +1,337 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres/board/dao"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type CardStore struct {
+	db *pgxpool.Pool
+	q  *dao.Queries
+}
+
+func NewCardStore(db *pgxpool.Pool) *CardStore {
+	return &CardStore{db, dao.New(db)}
+}
+
+func (s *CardStore) Insert(card *domain.Card) (*domain.Card, error) {
+	op := "postgres.CardStore.Insert"
+
+	boardUuid, err := parseUUID(op, card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	columnUuid, err := parseUUID(op, card.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+	assigneeUuids, err := parseUUIDs(op, card.AssigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbCard dao.Card
+	err = inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		err := lockColumn(ctx, op, q, *columnUuid, *boardUuid)
+		if err != nil {
+			return err
+		}
+
+		lastRank, err := optionalRank(q.LastCardRank(ctx, dao.LastCardRankParams{ColumnID: *columnUuid}))
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+
+		dbCard, err = q.InsertCard(ctx, dao.InsertCardParams{
+			BoardID:     *boardUuid,
+			ColumnID:    *columnUuid,
+			Title:       card.Title,
+			Description: card.Description,
+			AssigneeIds: assigneeUuids,
+			DueDate:     toNullTime(card.DueDate),
+			Rank:        domain.RankBetween(lastRank, ""),
+		})
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainCard(dbCard), nil
+}
+
+func (s *CardStore) Update(card *domain.Card) (*domain.Card, error) {
+	op := "postgres.CardStore.Update"
+
+	cardUuid, err := parseUUID(op, card.CardID)
+	if err != nil {
+		return nil, err
+	}
+	assigneeUuids, err := parseUUIDs(op, card.AssigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbCard, err := s.q.UpdateCard(ctx, dao.UpdateCardParams{
+		Title:       card.Title,
+		Description: card.Description,
+		AssigneeIds: assigneeUuids,
+		DueDate:     toNullTime(card.DueDate),
+		CardID:      *cardUuid,
+		Version:     card.Version,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, s.staleOrMissing(ctx, op, *cardUuid)
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainCard(dbCard), nil
+}
+
+func (s *CardStore) Remove(cardID string) error {
+	op := "postgres.CardStore.Remove"
+
+	cardUuid, err := parseUUID(op, cardID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = s.q.DeleteCard(ctx, *cardUuid)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "card does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+func (s *CardStore) Get(cardID string) (*domain.Card, error) {
+	op := "postgres.CardStore.Get"
+
+	cardUuid, err := parseUUID(op, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbCard, err := s.q.GetCard(ctx, *cardUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &boards.Error{Code: boards.ENOTFOUND, Message: "card does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainCard(dbCard), nil
+}
+
+func (s *CardStore) ListByBoard(boardID string) ([]*domain.Card, error) {
+	op := "postgres.CardStore.ListByBoard"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbCards, err := s.q.ListCardsByBoard(ctx, *boardUuid)
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	result := make([]*domain.Card, 0, len(dbCards))
+	for _, dbCard := range dbCards {
+		result = append(result, toDomainCard(dbCard))
+	}
+	return result, nil
+}
+
+func (s *CardStore) Move(cardID, toColumnID, beforeCardID string, version int32) (*domain.Card, error) {
+	op := "postgres.CardStore.Move"
+
+	cardUuid, err := parseUUID(op, cardID)
+	if err != nil {
+		return nil, err
+	}
+	columnUuid, err := parseUUID(op, toColumnID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbCard dao.Card
+	err = inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		card, err := q.GetCard(ctx, *cardUuid)
+		if err == pgx.ErrNoRows {
+			return &boards.Error{Code: boards.ENOTFOUND, Message: "card does not exist", Op: op, Err: err}
+		}
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		if card.Version != version {
+			return staleCardError(op)
+		}
+
+		// ranks in a column are only ever computed while holding the column lock, so two
+		// concurrent drops into the same column can never pick the same gap
+		err = lockColumn(ctx, op, q, *columnUuid, card.BoardID)
+		if err != nil {
+			return err
+		}
+
+		prevRank, nextRank, err := cardGap(ctx, op, q, card, *columnUuid, beforeCardID)
+		if err != nil {
+			return err
+		}
+
+		// the version check is repeated here because the card row itself is not locked until
+		// this update; a concurrent move that committed first leaves no row to update
+		dbCard, err = q.MoveCard(ctx, dao.MoveCardParams{
+			ColumnID: *columnUuid,
+			Rank:     domain.RankBetween(prevRank, nextRank),
+			CardID:   card.CardID,
+			Version:  version,
+		})
+		if err == pgx.ErrNoRows {
+			return staleCardError(op)
+		}
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainCard(dbCard), nil
+}
+
+// staleOrMissing explains why a versioned update touched no rows.
+func (s *CardStore) staleOrMissing(ctx context.Context, op string, cardID uuid.UUID) error {
+	_, err := s.q.GetCard(ctx, cardID)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "card does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return staleCardError(op)
+}
+
+func staleCardError(op string) error {
+	return &boards.Error{Code: boards.ECONFLICT, Message: "card was changed by someone else, reload and try again", Op: op}
+}
+
+// cardGap returns the ranks around the slot right before beforeCardID in columnID, or after the
+// last card of the column when beforeCardID is empty. The card being moved is ignored.
+func cardGap(ctx context.Context, op string, q *dao.Queries, card dao.Card, columnID uuid.UUID, beforeCardID string) (string, string, error) {
+	if beforeCardID == "" {
+		prevRank, err := optionalRank(q.LastCardRank(ctx, dao.LastCardRankParams{
+			ColumnID: columnID,
+			CardID:   card.CardID,
+		}))
+		if err != nil {
+			return "", "", &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return prevRank, "", nil
+	}
+
+	beforeUuid, err := parseUUID(op, beforeCardID)
+	if err != nil {
+		return "", "", err
+	}
+
+	before, err := q.GetCard(ctx, *beforeUuid)
+	if err == pgx.ErrNoRows || (err == nil && before.ColumnID != columnID) {
+		return "", "", &boards.Error{Code: boards.ENOTFOUND, Message: "card to move before does not exist in the column", Op: op, Err: err}
+	}
+	if err != nil {
+		return "", "", &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	prevRank, err := optionalRank(q.PreviousCardRank(ctx, dao.PreviousCardRankParams{
+		ColumnID: columnID,
+		Rank:     before.Rank,
+		CardID:   card.CardID,
+	}))
+	if err != nil {
+		return "", "", &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return prevRank, before.Rank, nil
+}
+
+// lockColumn locks the column row for the rest of the transaction and makes sure it is on boardID.
+func lockColumn(ctx context.Context, op string, q *dao.Queries, columnID, boardID uuid.UUID) error {
+	columnBoardID, err := q.LockColumn(ctx, columnID)
+	if err == pgx.ErrNoRows || (err == nil && columnBoardID != boardID) {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "column does not exist on the board", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+func parseUUIDs(op string, ids []string) ([]uuid.UUID, error) {
+	parsed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		idUuid, err := parseUUID(op, id)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, *idUuid)
+	}
+	return parsed, nil
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func toDomainCard(dbCard dao.Card) *domain.Card {
+	assigneeIDs := make([]string, 0, len(dbCard.AssigneeIds))
+	for _, assigneeID := range dbCard.AssigneeIds {
+		assigneeIDs = append(assigneeIDs, assigneeID.String())
+	}
+
+	var dueDate *time.Time
+	if dbCard.DueDate.Valid {
+		dueDate = &dbCard.DueDate.Time
+	}
+
+	return &domain.Card{
+		CardID:      dbCard.CardID.String(),
+		BoardID:     dbCard.BoardID.String(),
+		ColumnID:    dbCard.ColumnID.String(),
+		Title:       dbCard.Title,
+		Description: dbCard.Description,
+		AssigneeIDs: assigneeIDs,
+		DueDate:     dueDate,
+		Rank:        dbCard.Rank,
+		Version:     dbCard.Version,
+		CreatedAt:   dbCard.CreatedAt,
+		ModifiedAt:  dbCard.ModifiedAt,
+	}
+}
@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres/board/dao"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ColumnStore struct {
+	db *pgxpool.Pool
+	q  *dao.Queries
+}
+
+func NewColumnStore(db *pgxpool.Pool) *ColumnStore {
+	return &ColumnStore{db, dao.New(db)}
+}
+
+func (s *ColumnStore) Insert(column *domain.Column) (*domain.Column, error) {
+	op := "postgres.ColumnStore.Insert"
+
+	boardUuid, err := parseUUID(op, column.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbColumn dao.BoardColumn
+	err = inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		err := lockBoard(ctx, op, q, *boardUuid)
+		if err != nil {
+			return err
+		}
+
+		lastRank, err := optionalRank(q.LastColumnRank(ctx, dao.LastColumnRankParams{BoardID: *boardUuid}))
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+
+		dbColumn, err = q.InsertColumn(ctx, dao.InsertColumnParams{
+			BoardID: *boardUuid,
+			Title:   column.Title,
+			Rank:    domain.RankBetween(lastRank, ""),
+		})
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainColumn(dbColumn), nil
+}
+
+func (s *ColumnStore) Update(column *domain.Column) (*domain.Column, error) {
+	op := "postgres.ColumnStore.Update"
+
+	columnUuid, err := parseUUID(op, column.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbColumn, err := s.q.UpdateColumnTitle(ctx, dao.UpdateColumnTitleParams{
+		Title:    column.Title,
+		ColumnID: *columnUuid,
+		Version:  column.Version,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, s.staleOrMissing(ctx, op, *columnUuid)
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainColumn(dbColumn), nil
+}
+
+func (s *ColumnStore) Remove(columnID string) error {
+	op := "postgres.ColumnStore.Remove"
+
+	columnUuid, err := parseUUID(op, columnID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = s.q.DeleteColumn(ctx, *columnUuid)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "column does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+func (s *ColumnStore) Get(columnID string) (*domain.Column, error) {
+	op := "postgres.ColumnStore.Get"
+
+	columnUuid, err := parseUUID(op, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbColumn, err := s.q.GetColumn(ctx, *columnUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &boards.Error{Code: boards.ENOTFOUND, Message: "column does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainColumn(dbColumn), nil
+}
+
+func (s *ColumnStore) ListByBoard(boardID string) ([]*domain.Column, error) {
+	op := "postgres.ColumnStore.ListByBoard"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbColumns, err := s.q.ListColumnsByBoard(ctx, *boardUuid)
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	result := make([]*domain.Column, 0, len(dbColumns))
+	for _, dbColumn := range dbColumns {
+		result = append(result, toDomainColumn(dbColumn))
+	}
+	return result, nil
+}
+
+func (s *ColumnStore) Move(columnID, beforeColumnID string) (*domain.Column, error) {
+	op := "postgres.ColumnStore.Move"
+
+	columnUuid, err := parseUUID(op, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbColumn dao.BoardColumn
+	err = inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		column, err := q.GetColumn(ctx, *columnUuid)
+		if err == pgx.ErrNoRows {
+			return &boards.Error{Code: boards.ENOTFOUND, Message: "column does not exist", Op: op, Err: err}
+		}
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+
+		// ranks are only ever computed while holding the board lock, so two concurrent moves
+		// can never pick the same gap
+		err = lockBoard(ctx, op, q, column.BoardID)
+		if err != nil {
+			return err
+		}
+
+		prevRank, nextRank, err := columnGap(ctx, op, q, column, beforeColumnID)
+		if err != nil {
+			return err
+		}
+
+		dbColumn, err = q.UpdateColumnRank(ctx, dao.UpdateColumnRankParams{
+			Rank:     domain.RankBetween(prevRank, nextRank),
+			ColumnID: column.ColumnID,
+		})
+		if err != nil {
+			return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainColumn(dbColumn), nil
+}
+
+// columnGap returns the ranks around the slot right before beforeColumnID, or after the last
+// column of the board when beforeColumnID is empty. The column being moved is ignored.
+func columnGap(ctx context.Context, op string, q *dao.Queries, column dao.BoardColumn, beforeColumnID string) (string, string, error) {
+	if beforeColumnID == "" {
+		prevRank, err := optionalRank(q.LastColumnRank(ctx, dao.LastColumnRankParams{
+			BoardID:  column.BoardID,
+			ColumnID: column.ColumnID,
+		}))
+		if err != nil {
+			return "", "", &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		return prevRank, "", nil
+	}
+
+	beforeUuid, err := parseUUID(op, beforeColumnID)
+	if err != nil {
+		return "", "", err
+	}
+
+	before, err := q.GetColumn(ctx, *beforeUuid)
+	if err == pgx.ErrNoRows || (err == nil && before.BoardID != column.BoardID) {
+		return "", "", &boards.Error{Code: boards.ENOTFOUND, Message: "column to move before does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return "", "", &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	prevRank, err := optionalRank(q.PreviousColumnRank(ctx, dao.PreviousColumnRankParams{
+		BoardID:  column.BoardID,
+		Rank:     before.Rank,
+		ColumnID: column.ColumnID,
+	}))
+	if err != nil {
+		return "", "", &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return prevRank, before.Rank, nil
+}
+
+func lockBoard(ctx context.Context, op string, q *dao.Queries, boardID uuid.UUID) error {
+	_, err := q.LockBoard(ctx, boardID)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "board does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+// optionalRank treats a missing neighbour as the open end of the list.
+func optionalRank(rank string, err error) (string, error) {
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return rank, err
+}
+
+// staleOrMissing explains why a versioned update touched no rows.
+func (s *ColumnStore) staleOrMissing(ctx context.Context, op string, columnID uuid.UUID) error {
+	_, err := s.q.GetColumn(ctx, columnID)
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "column does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return &boards.Error{Code: boards.ECONFLICT, Message: "column was changed by someone else, reload and try again", Op: op}
+}
+
+func toDomainColumn(dbColumn dao.BoardColumn) *domain.Column {
+	return &domain.Column{
+		ColumnID:   dbColumn.ColumnID.String(),
+		BoardID:    dbColumn.BoardID.String(),
+		Title:      dbColumn.Title,
+		Rank:       dbColumn.Rank,
+		Version:    dbColumn.Version,
+		CreatedAt:  dbColumn.CreatedAt,
+		ModifiedAt: dbColumn.ModifiedAt,
+	}
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres/board/dao"
+	"github.com/jackc/pgx/v4"
+)
+
+type MemberStore struct {
+	q *dao.Queries
+}
+
+func NewMemberStore(q *dao.Queries) *MemberStore {
+	return &MemberStore{q}
+}
+
+func (s *MemberStore) Insert(member *domain.Member) (*domain.Member, error) {
+	op := "postgres.MemberStore.Insert"
+
+	boardUuid, err := parseUUID(op, member.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	userUuid, err := parseUUID(op, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbMember, err := s.q.InsertMember(ctx, dao.InsertMemberParams{
+		BoardID: *boardUuid,
+		UserID:  *userUuid,
+		Role:    string(member.Role),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, &boards.Error{Code: boards.ECONFLICT, Message: "user already is a member of the board", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainMember(dbMember), nil
+}
+
+func (s *MemberStore) UpdateRole(member *domain.Member) (*domain.Member, error) {
+	op := "postgres.MemberStore.UpdateRole"
+
+	boardUuid, err := parseUUID(op, member.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	userUuid, err := parseUUID(op, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbMember, err := s.q.UpdateMemberRole(ctx, dao.UpdateMemberRoleParams{
+		Role:    string(member.Role),
+		BoardID: *boardUuid,
+		UserID:  *userUuid,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, &boards.Error{Code: boards.ENOTFOUND, Message: "member does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainMember(dbMember), nil
+}
+
+func (s *MemberStore) Remove(boardID, userID string) error {
+	op := "postgres.MemberStore.Remove"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return err
+	}
+	userUuid, err := parseUUID(op, userID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = s.q.DeleteMember(ctx, dao.DeleteMemberParams{
+		BoardID: *boardUuid,
+		UserID:  *userUuid,
+	})
+	if err == pgx.ErrNoRows {
+		return &boards.Error{Code: boards.ENOTFOUND, Message: "member does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+func (s *MemberStore) Get(boardID, userID string) (*domain.Member, error) {
+	op := "postgres.MemberStore.Get"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return nil, err
+	}
+	userUuid, err := parseUUID(op, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbMember, err := s.q.GetMember(ctx, dao.GetMemberParams{
+		BoardID: *boardUuid,
+		UserID:  *userUuid,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, &boards.Error{Code: boards.ENOTFOUND, Message: "member does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toDomainMember(dbMember), nil
+}
+
+func (s *MemberStore) ListByBoard(boardID string) ([]*domain.Member, error) {
+	op := "postgres.MemberStore.ListByBoard"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dbMembers, err := s.q.ListMembersByBoard(ctx, *boardUuid)
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	result := make([]*domain.Member, 0, len(dbMembers))
+	for _, dbMember := range dbMembers {
+		result = append(result, &domain.Member{
+			BoardID:   dbMember.BoardID.String(),
+			UserID:    dbMember.UserID.String(),
+			Name:      dbMember.Name,
+			Email:     dbMember.Email,
+			Role:      domain.Role(dbMember.Role),
+			CreatedAt: dbMember.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (s *MemberStore) CountByRole(boardID string, role domain.Role) (int, error) {
+	op := "postgres.MemberStore.CountByRole"
+
+	boardUuid, err := parseUUID(op, boardID)
+	if err != nil {
+		return -1, err
+	}
+
+	ctx := context.Background()
+	count, err := s.q.CountMembersByRole(ctx, dao.CountMembersByRoleParams{
+		BoardID: *boardUuid,
+		Role:    string(role),
+	})
+	if err != nil {
+		return -1, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	return int(count), nil
+}
+
+func toDomainMember(dbMember dao.BoardMember) *domain.Member {
+	return &domain.Member{
+		BoardID:   dbMember.BoardID.String(),
+		UserID:    dbMember.UserID.String(),
+		Role:      domain.Role(dbMember.Role),
+		CreatedAt: dbMember.CreatedAt,
+	}
+}
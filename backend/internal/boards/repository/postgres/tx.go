@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/repository/postgres/board/dao"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// inTx runs fn with queries bound to a single transaction, committing when fn succeeds. Errors
+// returned by fn are passed through untouched, so fn is expected to return *boards.Error values.
+func inTx(op string, db *pgxpool.Pool, fn func(q *dao.Queries) error) error {
+	ctx := context.Background()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	defer tx.Rollback(ctx)
+
+	err = fn(dao.New(tx))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
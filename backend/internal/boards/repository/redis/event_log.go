@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"strconv"
+	"strings"
+)
+
+// EventLog reads the per board streams EventPublisher appends to.
+type EventLog struct {
+	client *redis.Client
+}
+
+func NewEventLog(client *redis.Client) *EventLog {
+	return &EventLog{client}
+}
+
+func (l *EventLog) Since(boardID, lastEventID string) ([]*domain.Event, error) {
+	op := "redis.EventLog.Since"
+
+	lastMs, lastSeq, err := parseStreamID(lastEventID)
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINVALID, Message: fmt.Sprintf("invalid event id (%s)", lastEventID), Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+
+	// nothing after lastEventID was trimmed as long as the log still reaches back to it
+	retained, err := l.client.XRangeN(ctx, stream(boardID), lastEventID, lastEventID, 1).Result()
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+	if len(retained) == 0 {
+		oldest, err := l.client.XRangeN(ctx, stream(boardID), "-", "+", 1).Result()
+		if err != nil {
+			return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		if len(oldest) == 0 || streamIDAfter(oldest[0].ID, lastMs, lastSeq) {
+			return nil, &boards.Error{Code: boards.ENOTFOUND, Message: "events since the given id are no longer available, reload the board", Op: op}
+		}
+	}
+
+	entries, err := l.client.XRange(ctx, stream(boardID), "("+lastEventID, "+").Result()
+	if err != nil {
+		return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+	}
+
+	events := make([]*domain.Event, 0, len(entries))
+	for _, entry := range entries {
+		encodedEvent, ok := entry.Values[eventField].(string)
+		if !ok {
+			return nil, &boards.Error{Code: boards.EINTERNAL, Message: fmt.Sprintf("malformed event log entry (%s)", entry.ID), Op: op}
+		}
+
+		var event domain.Event
+		err = json.Unmarshal([]byte(encodedEvent), &event)
+		if err != nil {
+			return nil, &boards.Error{Code: boards.EINTERNAL, Op: op, Err: err}
+		}
+		event.ID = entry.ID
+
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+// parseStreamID splits a Redis stream entry ID ("<milliseconds>-<sequence>") into its parts.
+func parseStreamID(id string) (uint64, uint64, error) {
+	parts := strings.Split(id, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("stream id must have the form <ms>-<seq>")
+	}
+
+	ms, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return ms, seq, nil
+}
+
+func streamIDAfter(id string, ms, seq uint64) bool {
+	idMs, idSeq, err := parseStreamID(id)
+	if err != nil {
+		return false
+	}
+	return idMs > ms || (idMs == ms && idSeq > seq)
+}
@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"time"
+)
+
+const (
+	// channelPrefix namespaces board event channels, one channel per board.
+	channelPrefix = "board-events:"
+	// streamPrefix namespaces board event logs, one stream per board.
+	streamPrefix = "board-event-log:"
+	// streamLength is roughly how many events are kept per board for clients to catch up on.
+	streamLength = 1000
+	// streamTTL drops the logs of boards nobody touched in a while.
+	streamTTL = 24 * time.Hour
+	// eventField is the stream entry field that holds the encoded event.
+	eventField = "event"
+)
+
+func channel(boardID string) string {
+	return channelPrefix + boardID
+}
+
+func stream(boardID string) string {
+	return streamPrefix + boardID
+}
+
+// EventPublisher appends board events to the board's event log and then publishes them to Redis,
+// from where an EventRelay on every instance hands them to its local subscribers.
+type EventPublisher struct {
+	client *redis.Client
+	log    logging.Logger
+}
+
+func NewEventPublisher(client *redis.Client, log logging.Logger) *EventPublisher {
+	return &EventPublisher{client, log}
+}
+
+func (p *EventPublisher) Publish(event *domain.Event) {
+	ctx := context.Background()
+
+	err := p.append(ctx, event)
+	if err != nil {
+		// live subscribers still get the event, only catching up on it later is not possible
+		p.log.Warn(fmt.Sprintf("unable to log board event. type: %s, boardId: %s, err: %s", event.Type, event.BoardID, err.Error()))
+	}
+
+	encodedEvent, err := json.Marshal(event)
+	if err != nil {
+		p.log.Warn(fmt.Sprintf("unable to encode board event. type: %s, err: %s", event.Type, err.Error()))
+		return
+	}
+
+	err = p.client.Publish(ctx, channel(event.BoardID), encodedEvent).Err()
+	if err != nil {
+		p.log.Warn(fmt.Sprintf("unable to publish board event. type: %s, boardId: %s, err: %s", event.Type, event.BoardID, err.Error()))
+	}
+}
+
+// append adds the event to the board's stream and sets event.ID to the ID of the entry.
+func (p *EventPublisher) append(ctx context.Context, event *domain.Event) error {
+	encodedEvent, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	id, err := p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream(event.BoardID),
+		MaxLen: streamLength,
+		Approx: true,
+		ID:     "*",
+		Values: []interface{}{eventField, encodedEvent},
+	}).Result()
+	if err != nil {
+		return err
+	}
+	event.ID = id
+
+	return p.client.Expire(ctx, stream(event.BoardID), streamTTL).Err()
+}
@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/boards/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+)
+
+// EventRelay forwards the board events published on any instance to the publisher of this
+// instance, usually the in-process hub its WebSocket and SSE subscribers listen on.
+type EventRelay struct {
+	client *redis.Client
+	local  ports.EventPublisher
+	log    logging.Logger
+}
+
+func NewEventRelay(client *redis.Client, local ports.EventPublisher, log logging.Logger) *EventRelay {
+	return &EventRelay{client, local, log}
+}
+
+// Run relays events until ctx is done. The Redis client re-subscribes on its own after
+// connection errors; events published while it is reconnecting are lost.
+func (r *EventRelay) Run(ctx context.Context) error {
+	op := "redis.EventRelay.Run"
+
+	pubsub := r.client.PSubscribe(ctx, channelPrefix+"*")
+	defer pubsub.Close()
+
+	// wait for the subscription to be confirmed, so a bad connection fails at startup
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		return &boards.Error{Op: op, Err: err}
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var event domain.Event
+			err = json.Unmarshal([]byte(message.Payload), &event)
+			if err != nil {
+				r.log.Warn(fmt.Sprintf("unable to decode board event. channel: %s, err: %s", message.Channel, err.Error()))
+				continue
+			}
+
+			r.local.Publish(&event)
+		}
+	}
+}
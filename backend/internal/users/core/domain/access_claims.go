@@ -1,7 +1,27 @@
 package domain
 
+import "time"
+
 type AccessClaims struct {
-	UserID string
+	UserID    string
+	SessionID string
+	// TokenID is unique to every token.
+	TokenID string
+	Roles   []string
+	// Scopes limit what the token may be used for.
+	Scopes    []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the token may be used for scope.
+func (c *AccessClaims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
 }
 
 type AccessToken string
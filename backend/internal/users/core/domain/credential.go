@@ -1,7 +1,17 @@
 package domain
 
+import "time"
+
 type Credential struct {
 	UserID   string
 	Email    string
 	Password string
+	// EmailVerifiedAt is nil until the user proves they own Email.
+	EmailVerifiedAt *time.Time
+	// DeletedAt is set while the account waits to be purged.
+	DeletedAt *time.Time
+	// Roles are granted across the whole app, as opposed to roles on a board.
+	Roles []string
+	// LockedAt is set while an admin keeps the user from logging in.
+	LockedAt *time.Time
 }
@@ -0,0 +1,10 @@
+package domain
+
+import "strings"
+
+// NormalizeEmail returns the form emails are stored and compared in. Only the case of the domain
+// is insignificant by the standard, but treating the whole address as case-insensitive is what
+// users expect, and what mail providers do.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
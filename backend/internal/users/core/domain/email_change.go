@@ -0,0 +1,9 @@
+package domain
+
+// EmailChange is a change of address the user asked for, which waits for them to confirm that
+// they own the new address.
+type EmailChange struct {
+	UserID   string
+	OldEmail string
+	NewEmail string
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{"already normalized", "ada@example.com", "ada@example.com"},
+		{"uppercase domain", "ada@EXAMPLE.com", "ada@example.com"},
+		{"uppercase local part", "Ada.Lovelace@example.com", "ada.lovelace@example.com"},
+		{"surrounding whitespace", "  ada@example.com\t\n", "ada@example.com"},
+		{"inner whitespace kept", "ada @example.com", "ada @example.com"},
+		{"plus addressing kept", "Ada+Boards@Example.com", "ada+boards@example.com"},
+		{"dots kept", "a.d.a@example.com", "a.d.a@example.com"},
+		{"non-ascii", "ÄDA@Exämple.com", "äda@exämple.com"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeEmail(tt.email); got != tt.want {
+				t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+// ExternalIdentity is a user's account at an identity provider, identified by the provider's
+// subject. Email is whatever address the provider reported when the identity was linked.
+type ExternalIdentity struct {
+	Provider string
+	Subject  string
+	UserID   string
+	Email    string
+	// EmailVerified is whether the provider vouches for Email. It is only known during login.
+	EmailVerified bool
+}
+
+// OAuthState is what a login through an identity provider needs to remember between sending the
+// user to the provider and the provider sending them back.
+type OAuthState struct {
+	Provider string
+	// Verifier is the PKCE code verifier.
+	Verifier string
+	Nonce    string
+	// LinkUserID is set when a logged-in user links the identity to their account, rather than
+	// logging in with it.
+	LinkUserID string
+}
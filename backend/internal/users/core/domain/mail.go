@@ -0,0 +1,7 @@
+package domain
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
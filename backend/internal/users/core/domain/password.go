@@ -0,0 +1,10 @@
+package domain
+
+// Reasons a password is rejected, for clients to tell users what to fix.
+const (
+	PasswordTooShort         = "password_too_short"
+	PasswordTooLong          = "password_too_long"
+	PasswordTooFewClasses    = "password_too_few_classes"
+	PasswordContainsPersonal = "password_contains_personal"
+	PasswordBreached         = "password_breached"
+)
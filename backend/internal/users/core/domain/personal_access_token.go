@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// PersonalAccessTokenPrefix starts every personal access token, which tells them apart from
+// access tokens issued at login, and makes them easy to spot when they leak.
+const PersonalAccessTokenPrefix = "kbpat_"
+
+// PersonalAccessToken lets scripts act as the user without logging in. The token itself is only
+// known when it is created.
+type PersonalAccessToken struct {
+	TokenID string
+	UserID  string
+	// Name tells the user what the token is for, e.g. "CI".
+	Name   string
+	Scopes []string
+	// ExpiresAt is nil for tokens that work until they are revoked.
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "crypto"
+
+// PublicKey verifies access tokens whose kid header is KeyID.
+type PublicKey struct {
+	KeyID string
+	// Algorithm is the JWS algorithm the key signs with, e.g. RS256 or EdDSA.
+	Algorithm string
+	Key       crypto.PublicKey
+}
@@ -0,0 +1,14 @@
+package domain
+
+// RoleAdmin is granted to the people running the app, who may help users with their accounts.
+const RoleAdmin = "admin"
+
+// HasRole reports whether the credential was granted role.
+func (c *Credential) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package domain
+
+const (
+	ScopeBoardsRead  = "boards:read"
+	ScopeBoardsWrite = "boards:write"
+	// ScopeAccount covers the user's own account: profile, password, sessions and the like.
+	ScopeAccount = "account"
+)
+
+// SessionScopes are granted to the tokens of a logged-in user, who may do anything.
+var SessionScopes = []string{ScopeBoardsRead, ScopeBoardsWrite, ScopeAccount}
+
+// PersonalAccessTokenScopes holds the scopes that may be granted to personal access tokens.
+// Managing the account is not among them, so a leaked token cannot be used to take the account
+// over.
+var PersonalAccessTokenScopes = map[string]bool{ScopeBoardsRead: true, ScopeBoardsWrite: true}
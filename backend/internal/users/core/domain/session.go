@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Client describes where a request to log in or refresh came from.
+type Client struct {
+	Device    string
+	IP        string
+	UserAgent string
+}
+
+// Session is one login of a user, kept alive by refreshing its tokens. SessionID is stable across
+// refreshes.
+type Session struct {
+	SessionID  string
+	UserID     string
+	Client     Client
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
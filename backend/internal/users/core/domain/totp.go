@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// TOTP is a user's authenticator app secret. It only becomes a second factor once ConfirmedAt is
+// set, which happens when the user proves the app generates valid codes.
+type TOTP struct {
+	UserID      string
+	Secret      string
+	ConfirmedAt *time.Time
+}
+
+// TOTPEnrollment is what the user needs to add the secret to their authenticator app.
+type TOTPEnrollment struct {
+	Secret string
+	// URI is an otpauth:// URI, usually shown as a QR code.
+	URI string
+}
+
+// MFAChallenge is handed out instead of tokens when a login still needs a second factor.
+type MFAChallenge string
@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+
+// AccessDenylist rejects access tokens before they expire. Entries only need to outlive the
+// tokens they reject, so none of them are kept for longer than an access token lives.
+type AccessDenylist interface {
+	// Deny rejects the token, by its ID.
+	Deny(*domain.AccessClaims) error
+	// DenySession rejects every token issued so far for the session.
+	DenySession(sessionID string) error
+	// DenyUser rejects every token issued so far to the user.
+	DenyUser(userID string) error
+	Denied(*domain.AccessClaims) (bool, error)
+}
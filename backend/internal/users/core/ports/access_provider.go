@@ -5,4 +5,6 @@ import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
 type AccessProvider interface {
 	Create(*domain.AccessClaims) (*domain.AccessToken, error)
 	Verify(*domain.AccessToken) (*domain.AccessClaims, error)
+	// PublicKeys returns the keys others can verify access tokens with.
+	PublicKeys() []*domain.PublicKey
 }
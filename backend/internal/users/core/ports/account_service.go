@@ -0,0 +1,225 @@
+package ports
+
+import (
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+type AccountService struct {
+	credentialStore CredentialStore
+	refreshStore    RefreshStore
+	accessDenylist  AccessDenylist
+	personalTokens  *PersonalAccessTokenService
+	loginLimiter    LoginLimiter
+	passwordHasher  PasswordHasher
+	activity        activityPorts.Recorder
+	// gracePeriod is how long a deleted account can still be restored before it is purged.
+	gracePeriod time.Duration
+}
+
+func NewAccountService(credentialStore CredentialStore, refreshStore RefreshStore, accessDenylist AccessDenylist, personalTokens *PersonalAccessTokenService, loginLimiter LoginLimiter, passwordHasher PasswordHasher, activity activityPorts.Recorder, gracePeriod time.Duration) *AccountService {
+	return &AccountService{credentialStore, refreshStore, accessDenylist, personalTokens, loginLimiter, passwordHasher, activity, gracePeriod}
+}
+
+// Delete schedules the account for purging, logs the user out everywhere and revokes their
+// personal access tokens. It returns when the account will be purged.
+func (s *AccountService) Delete(userID string) (time.Time, error) {
+	op := "ports.AccountService.Delete"
+
+	credential, err := s.credentialStore.MarkDeleted(userID)
+	if err != nil {
+		return time.Time{}, &users.Error{Op: op, Err: err}
+	}
+
+	err = s.refreshStore.RevokeAllSessions(userID)
+	if err != nil {
+		return time.Time{}, &users.Error{Op: op, Err: err}
+	}
+
+	err = s.accessDenylist.DenyUser(userID)
+	if err != nil {
+		return time.Time{}, &users.Error{Op: op, Err: err}
+	}
+
+	err = s.personalTokens.RevokeAll(userID)
+	if err != nil {
+		return time.Time{}, &users.Error{Op: op, Err: err}
+	}
+
+	purgeAt := credential.DeletedAt.Add(s.gracePeriod)
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityDomain.TypeUserDeleted,
+		SubjectID: userID,
+		Details:   map[string]string{"purge_at": purgeAt.Format(time.RFC3339)},
+	})
+
+	return purgeAt, nil
+}
+
+// Restore undoes Delete during the grace period. Deleted users cannot log in, so they prove who
+// they are with their password instead of an access token. Like LogIn, failed attempts count
+// towards the lockout of the account and the IP address, and the password is only checked for
+// accounts that can be restored, so Restore cannot be used to guess the passwords of others.
+func (s *AccountService) Restore(email, password, ip string) error {
+	op := "ports.AccountService.Restore"
+	msg := "email or password is incorrect, or the account cannot be restored"
+
+	email = domain.NormalizeEmail(email)
+
+	err := s.loginLimiter.Check(email, ip)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := s.credentialStore.FindByEmail(email)
+	if err != nil && users.ErrorCode(err) != users.ENOTFOUND {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	if err != nil || credential.DeletedAt == nil || time.Since(*credential.DeletedAt) > s.gracePeriod {
+		failErr := s.loginLimiter.Fail(email, ip)
+		if failErr != nil {
+			return &users.Error{Op: op, Err: failErr}
+		}
+		return &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+	}
+
+	passwordValid, err := s.passwordHasher.Verify(password, credential.Password)
+	if err != nil || !passwordValid {
+		failErr := s.loginLimiter.Fail(email, ip)
+		if failErr != nil {
+			return &users.Error{Op: op, Err: failErr}
+		}
+		return &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+	}
+
+	err = s.loginLimiter.Succeed(email)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	_, err = s.credentialStore.Restore(credential.UserID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserRestored, credential.UserID)
+	return nil
+}
+
+// Lock keeps the user from logging in until an admin unlocks them, for accounts that were taken
+// over or are being abused. The user is logged out everywhere and their personal access tokens
+// are revoked. Only admins may lock accounts.
+func (s *AccountService) Lock(adminID, userID string) error {
+	op := "ports.AccountService.Lock"
+
+	err := s.requireAdmin(adminID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	if adminID == userID {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "admins cannot lock themselves"}
+	}
+
+	_, err = s.credentialStore.Lock(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.refreshStore.RevokeAllSessions(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.accessDenylist.DenyUser(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.personalTokens.RevokeAll(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   adminID,
+		Type:      activityDomain.TypeUserLocked,
+		SubjectID: userID,
+	})
+	return nil
+}
+
+// Unlock lifts a lock set by Lock as well as the lockout of an account that failed to log in too
+// often. Only admins may unlock accounts.
+func (s *AccountService) Unlock(adminID, userID string) error {
+	op := "ports.AccountService.Unlock"
+
+	err := s.requireAdmin(adminID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := s.credentialStore.Unlock(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.loginLimiter.Unlock(credential.Email)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   adminID,
+		Type:      activityDomain.TypeUserUnlocked,
+		SubjectID: userID,
+	})
+	return nil
+}
+
+// Purge removes every account whose grace period is over, and returns how many it removed.
+func (s *AccountService) Purge() (int, error) {
+	op := "ports.AccountService.Purge"
+
+	userIDs, err := s.credentialStore.ListDeletedBefore(time.Now().Add(-s.gracePeriod))
+	if err != nil {
+		return 0, &users.Error{Op: op, Err: err}
+	}
+
+	for i, userID := range userIDs {
+		err = s.credentialStore.Remove(&domain.Credential{UserID: userID})
+		if err != nil {
+			return i, &users.Error{Op: op, Err: err}
+		}
+
+		s.record(activityDomain.TypeUserPurged, userID)
+	}
+
+	return len(userIDs), nil
+}
+
+// requireAdmin fails with users.EFORBIDDEN unless the user is an admin.
+func (s *AccountService) requireAdmin(userID string) error {
+	op := "ports.AccountService.requireAdmin"
+
+	credential, err := s.credentialStore.FindById(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	if !credential.HasRole(domain.RoleAdmin) {
+		return &users.Error{Op: op, Code: users.EFORBIDDEN, Message: "only admins can lock and unlock accounts"}
+	}
+	return nil
+}
+
+func (s *AccountService) record(activityType activityDomain.Type, userID string) {
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityType,
+		SubjectID: userID,
+	})
+}
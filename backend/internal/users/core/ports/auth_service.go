@@ -2,24 +2,47 @@ package ports
 
 import (
 	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
 	"github.com/hardiksachan/kanban_board/backend/internal/users"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
-	"golang.org/x/crypto/bcrypt"
+	"strings"
 )
 
 type AuthService struct {
 	credentialStore CredentialStore
 	refreshStore    RefreshStore
 	accessProvider  AccessProvider
+	accessDenylist  AccessDenylist
+	activity        activityPorts.Recorder
+	verification    VerificationSender
+	mfa             *MFAService
+	challengeStore  ChallengeStore
+	loginLimiter    LoginLimiter
+	passwordPolicy  PasswordPolicy
+	passwordHasher  PasswordHasher
+	personalTokens  *PersonalAccessTokenService
+	// requireVerifiedEmail keeps users from logging in before they verified their address.
+	requireVerifiedEmail bool
 }
 
-func NewAuthService(credentialStore CredentialStore, accessProvider AccessProvider, refreshStore RefreshStore) *AuthService {
-	return &AuthService{credentialStore, refreshStore, accessProvider}
+func NewAuthService(credentialStore CredentialStore, accessProvider AccessProvider, accessDenylist AccessDenylist, refreshStore RefreshStore, activity activityPorts.Recorder, verification VerificationSender, mfa *MFAService, challengeStore ChallengeStore, loginLimiter LoginLimiter, passwordPolicy PasswordPolicy, passwordHasher PasswordHasher, personalTokens *PersonalAccessTokenService, requireVerifiedEmail bool) *AuthService {
+	return &AuthService{credentialStore, refreshStore, accessProvider, accessDenylist, activity, verification, mfa, challengeStore, loginLimiter, passwordPolicy, passwordHasher, personalTokens, requireVerifiedEmail}
 }
 
-func (a *AuthService) SignUp(credential *domain.Credential) (*domain.Credential, error) {
+// SignUp creates the credential of a new user and mails them a verification link. The password
+// must follow the password policy, which among other things keeps it from containing the user's
+// name.
+func (a *AuthService) SignUp(credential *domain.Credential, name string) (*domain.Credential, error) {
 	op := "ports.AuthService.SignUp"
 
+	credential.Email = domain.NormalizeEmail(credential.Email)
+
+	err := a.passwordPolicy.Check(credential.Password, credential.Email, name)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
 	count, err := a.credentialStore.CountByEmail(credential.Email)
 	if err != nil {
 		return nil, &users.Error{Op: op, Err: err}
@@ -28,7 +51,7 @@ func (a *AuthService) SignUp(credential *domain.Credential) (*domain.Credential,
 		return nil, &users.Error{Op: op, Code: users.ECONFLICT, Message: fmt.Sprintf("credential with email (%s) exists", credential.Email)}
 	}
 
-	hashedPassword, err := HashPassword(credential.Password)
+	hashedPassword, err := a.passwordHasher.Hash(credential.Password)
 	if err != nil {
 		return nil, &users.Error{Op: op, Code: users.EINTERNAL}
 	}
@@ -39,29 +62,151 @@ func (a *AuthService) SignUp(credential *domain.Credential) (*domain.Credential,
 		return nil, &users.Error{Op: op, Err: err}
 	}
 
+	a.record(activityDomain.TypeUserSignedUp, storedCred.UserID)
+
+	a.verification.Send(storedCred)
+
 	return storedCred, nil
 }
 
-func (a *AuthService) LogIn(email, password string) (*domain.Credential, *domain.RefreshToken, *domain.AccessToken, error) {
+// LogIn checks the user's password. Users with two-factor authentication get a challenge instead
+// of tokens, which VerifyMFA exchanges for tokens given their second factor. Accounts and IP
+// addresses failing too often are locked out for a while, failing with users.ETOOMANY. Passwords
+// hashed with an outdated algorithm or cost are hashed again while the password is at hand.
+func (a *AuthService) LogIn(email, password string, client *domain.Client) (*domain.Credential, *domain.MFAChallenge, *domain.RefreshToken, *domain.AccessToken, error) {
 	op := "ports.AuthService.Login"
+
+	email = domain.NormalizeEmail(email)
 	msg := fmt.Sprintf("email(%s) or password incorrect", email)
 
+	err := a.loginLimiter.Check(email, client.IP)
+	if err != nil {
+		return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
 	credential, err := a.credentialStore.FindByEmail(email)
+	if users.ErrorCode(err) == users.ENOTFOUND {
+		failErr := a.loginLimiter.Fail(email, client.IP)
+		if failErr != nil {
+			return nil, nil, nil, nil, &users.Error{Op: op, Err: failErr}
+		}
+	}
 	if err != nil {
-		return nil, nil, nil, &users.Error{Op: op, Message: msg, Err: err}
+		return nil, nil, nil, nil, &users.Error{Op: op, Message: msg, Err: err}
 	}
 
-	passwordValid, err := VerifyPassword(password, credential.Password)
+	passwordValid, err := a.passwordHasher.Verify(password, credential.Password)
 	if err != nil || !passwordValid {
-		return nil, nil, nil, &users.Error{Op: op, Code: users.ECONFLICT, Message: msg, Err: err}
+		failErr := a.loginLimiter.Fail(email, client.IP)
+		if failErr != nil {
+			return nil, nil, nil, nil, &users.Error{Op: op, Err: failErr}
+		}
+		return nil, nil, nil, nil, &users.Error{Op: op, Code: users.ECONFLICT, Message: msg, Err: err}
+	}
+
+	if a.passwordHasher.NeedsRehash(credential.Password) {
+		err = a.rehash(credential, password)
+		if err != nil {
+			return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+		}
+	}
+
+	challenge, refreshToken, accessToken, err := a.LogInAs(credential, client)
+	if err != nil {
+		return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	// failed logins are forgotten once the user is logged in, which is after VerifyMFA for users
+	// with a second factor
+	if challenge == nil {
+		err = a.loginLimiter.Succeed(email)
+		if err != nil {
+			return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+		}
+	}
+
+	return credential, challenge, refreshToken, accessToken, nil
+}
+
+// LogInAs logs in a user who already proved who they are, with their password or through an
+// identity provider. Everything else LogIn checks still applies, including the second factor.
+func (a *AuthService) LogInAs(credential *domain.Credential, client *domain.Client) (*domain.MFAChallenge, *domain.RefreshToken, *domain.AccessToken, error) {
+	op := "ports.AuthService.LogInAs"
+
+	err := a.allowLogIn(credential)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	mfaEnabled, err := a.mfa.Enabled(credential.UserID)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+	if mfaEnabled {
+		challenge, err := a.challengeStore.Create(credential.UserID)
+		if err != nil {
+			return nil, nil, nil, &users.Error{Op: op, Err: err}
+		}
+		return challenge, nil, nil, nil
+	}
+
+	refreshToken, accessToken, err := a.startSession(credential, client)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	return nil, refreshToken, accessToken, nil
+}
+
+// VerifyMFA finishes a login that LogIn answered with a challenge, given a code from the user's
+// authenticator app or a recovery code. Wrong codes count as failed logins, like wrong passwords
+// in LogIn.
+func (a *AuthService) VerifyMFA(challenge *domain.MFAChallenge, code string, client *domain.Client) (*domain.Credential, *domain.RefreshToken, *domain.AccessToken, error) {
+	op := "ports.AuthService.VerifyMFA"
+
+	userID, err := a.challengeStore.Attempt(challenge)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := a.credentialStore.FindById(userID)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	err = a.loginLimiter.Check(credential.Email, client.IP)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	// the account may have been locked or deleted since the challenge was handed out
+	err = a.allowLogIn(credential)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
 	}
 
-	refreshToken, err := a.refreshStore.Create(credential)
+	err = a.mfa.Verify(userID, code)
+	if users.ErrorCode(err) == users.EINVALID {
+		failErr := a.loginLimiter.Fail(credential.Email, client.IP)
+		if failErr != nil {
+			return nil, nil, nil, &users.Error{Op: op, Err: failErr}
+		}
+	}
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	err = a.challengeStore.Complete(challenge)
+	if err != nil {
+		return nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	err = a.loginLimiter.Succeed(credential.Email)
 	if err != nil {
 		return nil, nil, nil, &users.Error{Op: op, Err: err}
 	}
 
-	accessToken, err := a.accessProvider.Create(&domain.AccessClaims{UserID: credential.UserID})
+	refreshToken, accessToken, err := a.startSession(credential, client)
 	if err != nil {
 		return nil, nil, nil, &users.Error{Op: op, Err: err}
 	}
@@ -69,19 +214,102 @@ func (a *AuthService) LogIn(email, password string) (*domain.Credential, *domain
 	return credential, refreshToken, accessToken, nil
 }
 
-func (a *AuthService) LogOut(refreshToken *domain.RefreshToken) error {
+// allowLogIn fails with users.EFORBIDDEN for accounts that can't log in, whatever they prove.
+func (a *AuthService) allowLogIn(credential *domain.Credential) error {
+	op := "ports.AuthService.allowLogIn"
+
+	if credential.DeletedAt != nil {
+		return &users.Error{Op: op, Code: users.EFORBIDDEN, Message: "account is scheduled for deletion, restore it to log in"}
+	}
+
+	if credential.LockedAt != nil {
+		return &users.Error{Op: op, Code: users.EFORBIDDEN, Message: "account is locked, ask an admin to unlock it"}
+	}
+
+	if a.requireVerifiedEmail && credential.EmailVerifiedAt == nil {
+		return &users.Error{Op: op, Code: users.EFORBIDDEN, Message: "verify your email address before logging in"}
+	}
+
+	return nil
+}
+
+// rehash stores the password hashed the way new passwords are.
+func (a *AuthService) rehash(credential *domain.Credential, password string) error {
+	hashedPassword, err := a.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	credential.Password = hashedPassword
+
+	return a.credentialStore.Update(credential)
+}
+
+// startSession hands out the tokens of a new session to a user who is done logging in.
+func (a *AuthService) startSession(credential *domain.Credential, client *domain.Client) (*domain.RefreshToken, *domain.AccessToken, error) {
+	session, refreshToken, err := a.refreshStore.Create(credential.UserID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accessToken, err := a.accessProvider.Create(sessionClaims(credential, session))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a.record(activityDomain.TypeUserLoggedIn, credential.UserID)
+
+	return refreshToken, accessToken, nil
+}
+
+// LogOut ends the session of the refresh token and rejects the access token the request was
+// made with.
+func (a *AuthService) LogOut(accessClaims *domain.AccessClaims, refreshToken *domain.RefreshToken) error {
 	op := "ports.AuthService.LogOut"
 
-	err := a.refreshStore.Revoke(refreshToken)
+	err := a.refreshStore.Revoke(accessClaims.UserID, refreshToken)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = a.accessDenylist.Deny(accessClaims)
 	if err != nil {
 		return &users.Error{Op: op, Err: err}
 	}
+
+	a.record(activityDomain.TypeUserLoggedOut, accessClaims.UserID)
 	return nil
 }
 
-func (a *AuthService) DecodeAccessToken(accessToken *domain.AccessToken) (*domain.Credential, error) {
+// DecodeAccessToken accepts access tokens issued at login as well as personal access tokens.
+func (a *AuthService) DecodeAccessToken(accessToken *domain.AccessToken) (*domain.Credential, *domain.AccessClaims, error) {
 	op := "ports.AuthService.DecodeAccessToken"
 
+	var accessClaims *domain.AccessClaims
+	var err error
+	if strings.HasPrefix(string(*accessToken), domain.PersonalAccessTokenPrefix) {
+		accessClaims, err = a.personalTokens.Authenticate(string(*accessToken))
+	} else {
+		accessClaims, err = a.verifyAccessToken(accessToken)
+	}
+	if err != nil {
+		return nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := a.credentialStore.FindById(accessClaims.UserID)
+	if err != nil {
+		return nil, nil, &users.Error{Op: op, Err: err}
+	}
+	if credential.DeletedAt != nil {
+		return nil, nil, &users.Error{Op: op, Message: "account is scheduled for deletion", Code: users.EINVALID}
+	}
+
+	return credential, accessClaims, nil
+}
+
+// verifyAccessToken checks an access token issued at login, which must not have been revoked.
+func (a *AuthService) verifyAccessToken(accessToken *domain.AccessToken) (*domain.AccessClaims, error) {
+	op := "ports.AuthService.verifyAccessToken"
+
 	accessClaims, err := a.accessProvider.Verify(accessToken)
 	if err != nil {
 		return nil, &users.Error{Op: op, Err: err}
@@ -90,45 +318,118 @@ func (a *AuthService) DecodeAccessToken(accessToken *domain.AccessToken) (*domai
 		return nil, &users.Error{Op: op, Message: "invalid access token", Code: users.EINVALID}
 	}
 
-	credential, err := a.credentialStore.FindById(accessClaims.UserID)
+	denied, err := a.accessDenylist.Denied(accessClaims)
 	if err != nil {
 		return nil, &users.Error{Op: op, Err: err}
 	}
+	if denied {
+		return nil, &users.Error{Op: op, Message: "access token was revoked", Code: users.EINVALID}
+	}
+
+	return accessClaims, nil
+}
 
-	return credential, nil
+// PublicKeys returns the keys that verify access tokens, for other services to check tokens
+// without asking this one.
+func (a *AuthService) PublicKeys() []*domain.PublicKey {
+	return a.accessProvider.PublicKeys()
 }
 
-func (a *AuthService) RegenerateAccessToken(refreshToken *domain.RefreshToken) (encodedAccessToken *domain.AccessToken, err error) {
-	op := "ports.AuthService.RegenerateAccessToken"
+// Refresh exchanges a refresh token for a new access token and a new refresh token. The old
+// refresh token stops working; presenting it again revokes the whole login. The access token
+// carries the user's roles as they are now, not as they were at login.
+func (a *AuthService) Refresh(refreshToken *domain.RefreshToken, client *domain.Client) (*domain.RefreshToken, *domain.AccessToken, error) {
+	op := "ports.AuthService.Refresh"
 
-	credential, err := a.refreshStore.Verify(refreshToken)
+	session, rotatedToken, err := a.refreshStore.Rotate(refreshToken, client)
 	if err != nil {
-		return nil, &users.Error{Op: op, Err: err}
+		return nil, nil, &users.Error{Op: op, Err: err}
 	}
-	if credential == nil {
-		return nil, &users.Error{Op: op, Code: users.EINVALID, Err: err}
+
+	credential, err := a.credentialStore.FindById(session.UserID)
+	if err != nil {
+		return nil, nil, &users.Error{Op: op, Err: err}
 	}
 
-	accessToken, err := a.accessProvider.Create(&domain.AccessClaims{UserID: credential.UserID})
+	accessToken, err := a.accessProvider.Create(sessionClaims(credential, session))
+	if err != nil {
+		return nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	a.record(activityDomain.TypeUserTokenRefreshed, credential.UserID)
+
+	return rotatedToken, accessToken, nil
+}
+
+func (a *AuthService) ListSessions(userID string) ([]*domain.Session, error) {
+	op := "ports.AuthService.ListSessions"
+
+	sessions, err := a.refreshStore.ListSessions(userID)
 	if err != nil {
 		return nil, &users.Error{Op: op, Err: err}
 	}
 
-	return accessToken, nil
+	return sessions, nil
 }
 
-func HashPassword(pass string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.DefaultCost)
+// RevokeSession logs the user out on one device, rejecting the access tokens of the session too.
+func (a *AuthService) RevokeSession(userID, sessionID string) error {
+	op := "ports.AuthService.RevokeSession"
+
+	err := a.refreshStore.RevokeSession(userID, sessionID)
 	if err != nil {
-		return "", err
+		return &users.Error{Op: op, Err: err}
 	}
-	return string(hash), nil
+
+	err = a.accessDenylist.DenySession(sessionID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	a.record(activityDomain.TypeUserLoggedOut, userID)
+	return nil
 }
 
-func VerifyPassword(password, hashedPassword string) (bool, error) {
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+// RevokeAllSessions logs the user out everywhere, including the session making the request, and
+// revokes the user's personal access tokens.
+func (a *AuthService) RevokeAllSessions(userID string) error {
+	op := "ports.AuthService.RevokeAllSessions"
+
+	err := a.refreshStore.RevokeAllSessions(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = a.accessDenylist.DenyUser(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = a.personalTokens.RevokeAll(userID)
 	if err != nil {
-		return false, err
+		return &users.Error{Op: op, Err: err}
 	}
-	return true, nil
+
+	a.record(activityDomain.TypeUserLoggedOutEverywhere, userID)
+	return nil
+}
+
+// sessionClaims describes the access tokens of a logged-in user, which may be used for anything
+// the user may do.
+func sessionClaims(credential *domain.Credential, session *domain.Session) *domain.AccessClaims {
+	return &domain.AccessClaims{
+		UserID:    credential.UserID,
+		SessionID: session.SessionID,
+		Roles:     credential.Roles,
+		Scopes:    domain.SessionScopes,
+	}
+}
+
+// record writes account activity, which is always done by the user to themselves.
+func (a *AuthService) record(activityType activityDomain.Type, userID string) {
+	a.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityType,
+		SubjectID: userID,
+	})
 }
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+type ChallengeStore interface {
+	// Create issues a challenge for a user who got past the first factor, valid for a limited time.
+	Create(userID string) (*domain.MFAChallenge, error)
+	// Attempt counts an attempt at answering the challenge and returns the user it was issued
+	// for. Unknown and expired challenges, and challenges with too many attempts, fail with
+	// users.EINVALID.
+	Attempt(challenge *domain.MFAChallenge) (string, error)
+	// Complete invalidates the challenge once it was answered.
+	Complete(challenge *domain.MFAChallenge) error
+}
@@ -2,11 +2,27 @@ package ports
 
 import (
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
 )
 
 type CredentialStore interface {
 	Insert(*domain.Credential) (*domain.Credential, error)
 	Update(*domain.Credential) error
+	// MarkEmailVerified fails with users.ENOTFOUND when email is no longer the user's address.
+	MarkEmailVerified(userID, email string) (*domain.Credential, error)
+	// UpdateEmail replaces oldEmail with newEmail, which counts as verified. It fails with
+	// users.ENOTFOUND when oldEmail is no longer the user's address, and with users.ECONFLICT
+	// when newEmail belongs to another account.
+	UpdateEmail(userID, oldEmail, newEmail string) (*domain.Credential, error)
+	// MarkDeleted fails with users.ENOTFOUND when the account is already scheduled for deletion.
+	MarkDeleted(userID string) (*domain.Credential, error)
+	// Restore fails with users.ENOTFOUND when the account is not scheduled for deletion.
+	Restore(userID string) (*domain.Credential, error)
+	// Lock and Unlock fail with users.ENOTFOUND when the account does not exist.
+	Lock(userID string) (*domain.Credential, error)
+	Unlock(userID string) (*domain.Credential, error)
+	ListDeletedBefore(before time.Time) ([]string, error)
+	// Remove deletes the account for good, along with anything that only the user could reach.
 	Remove(*domain.Credential) error
 	FindByEmail(email string) (*domain.Credential, error)
 	FindById(UserId string) (*domain.Credential, error)
@@ -0,0 +1,11 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+
+type EmailChangeStore interface {
+	// Create issues a token confirming the change, valid for a limited time.
+	Create(*domain.EmailChange) (string, error)
+	// Consume returns the change the token was issued for and invalidates it, so every token
+	// works at most once. Unknown, used and expired tokens fail with users.ENOTFOUND.
+	Consume(token string) (*domain.EmailChange, error)
+}
@@ -0,0 +1,119 @@
+package ports
+
+import (
+	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"net/url"
+)
+
+type EmailService struct {
+	credentialStore CredentialStore
+	changeStore     EmailChangeStore
+	refreshStore    RefreshStore
+	accessDenylist  AccessDenylist
+	personalTokens  *PersonalAccessTokenService
+	mailer          Mailer
+	activity        activityPorts.Recorder
+	// confirmURL is the page of the web app that takes a confirmation token and confirms it.
+	confirmURL string
+}
+
+func NewEmailService(credentialStore CredentialStore, changeStore EmailChangeStore, refreshStore RefreshStore, accessDenylist AccessDenylist, personalTokens *PersonalAccessTokenService, mailer Mailer, activity activityPorts.Recorder, confirmURL string) *EmailService {
+	return &EmailService{credentialStore, changeStore, refreshStore, accessDenylist, personalTokens, mailer, activity, confirmURL}
+}
+
+// RequestChange mails a confirmation link to the new address, and tells the current one about
+// the request. Nothing changes until the link is opened.
+func (s *EmailService) RequestChange(userID, newEmail string) error {
+	op := "ports.EmailService.RequestChange"
+
+	newEmail = domain.NormalizeEmail(newEmail)
+
+	credential, err := s.credentialStore.FindById(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	if credential.Email == newEmail {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "this already is your email address"}
+	}
+
+	count, err := s.credentialStore.CountByEmail(newEmail)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	if count != 0 {
+		return &users.Error{Op: op, Code: users.ECONFLICT, Message: fmt.Sprintf("credential with email (%s) exists", newEmail)}
+	}
+
+	token, err := s.changeStore.Create(&domain.EmailChange{
+		UserID:   userID,
+		OldEmail: credential.Email,
+		NewEmail: newEmail,
+	})
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.mailer.Send(&domain.Mail{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body:    fmt.Sprintf("Confirm that this is your new address by opening %s?token=%s", s.confirmURL, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.mailer.Send(&domain.Mail{
+		To:      credential.Email,
+		Subject: "Your email address is about to change",
+		Body: fmt.Sprintf("Someone asked to change the email address of your account to %s. It changes once "+
+			"the new address is confirmed.\n\nIf it was not you, change your password now.", newEmail),
+	})
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+// ConfirmChange applies a change using a token from RequestChange, logs the user out everywhere
+// and revokes their personal access tokens. Changes requested for an address the user no longer
+// has are rejected.
+func (s *EmailService) ConfirmChange(token string) error {
+	op := "ports.EmailService.ConfirmChange"
+
+	change, err := s.changeStore.Consume(token)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	_, err = s.credentialStore.UpdateEmail(change.UserID, change.OldEmail, change.NewEmail)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.refreshStore.RevokeAllSessions(change.UserID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.accessDenylist.DenyUser(change.UserID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.personalTokens.RevokeAll(change.UserID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   change.UserID,
+		Type:      activityDomain.TypeUserEmailChanged,
+		SubjectID: change.UserID,
+	})
+	return nil
+}
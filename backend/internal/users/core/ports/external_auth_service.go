@@ -0,0 +1,234 @@
+package ports
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+// ExternalAuthService logs users in through identity providers. Begin hands out the URL to send
+// the user to, and Complete takes what the provider sends them back with.
+type ExternalAuthService struct {
+	auth            *AuthService
+	providers       map[string]IdentityProvider
+	stateStore      OAuthStateStore
+	identityStore   ExternalIdentityStore
+	credentialStore CredentialStore
+	activity        activityPorts.Recorder
+}
+
+func NewExternalAuthService(auth *AuthService, providers map[string]IdentityProvider, stateStore OAuthStateStore, identityStore ExternalIdentityStore, credentialStore CredentialStore, activity activityPorts.Recorder) *ExternalAuthService {
+	return &ExternalAuthService{auth, providers, stateStore, identityStore, credentialStore, activity}
+}
+
+// Begin starts a login through the provider, returning where to send the user.
+func (s *ExternalAuthService) Begin(providerName string) (string, error) {
+	op := "ports.ExternalAuthService.Begin"
+
+	authURL, err := s.begin(providerName, "")
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+	return authURL, nil
+}
+
+// BeginLink starts linking an identity at the provider to a logged-in user's account.
+func (s *ExternalAuthService) BeginLink(providerName, userID string) (string, error) {
+	op := "ports.ExternalAuthService.BeginLink"
+
+	authURL, err := s.begin(providerName, userID)
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+	return authURL, nil
+}
+
+// Complete logs in the user the provider vouches for. Identities seen for the first time are
+// linked to the account with the same address, or get a new account. Linking only happens when
+// both the provider and the account have verified the address, as anyone could have signed up
+// with it otherwise; those users link the identity from their account instead.
+func (s *ExternalAuthService) Complete(providerName, stateKey, code string, client *domain.Client) (*domain.Credential, *domain.MFAChallenge, *domain.RefreshToken, *domain.AccessToken, error) {
+	op := "ports.ExternalAuthService.Complete"
+
+	identity, err := s.exchange(providerName, stateKey, code, "")
+	if err != nil {
+		return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := s.resolve(identity)
+	if err != nil {
+		return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	challenge, refreshToken, accessToken, err := s.auth.LogInAs(credential, client)
+	if err != nil {
+		return nil, nil, nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	return credential, challenge, refreshToken, accessToken, nil
+}
+
+// CompleteLink links the identity the provider vouches for to the user who began linking it.
+func (s *ExternalAuthService) CompleteLink(providerName, userID, stateKey, code string) error {
+	op := "ports.ExternalAuthService.CompleteLink"
+
+	identity, err := s.exchange(providerName, stateKey, code, userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	identity.UserID = userID
+	err = s.link(identity)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+func (s *ExternalAuthService) begin(providerName, linkUserID string) (string, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	stateKey, err := s.stateStore.Create(&domain.OAuthState{
+		Provider:   providerName,
+		Verifier:   verifier,
+		Nonce:      nonce,
+		LinkUserID: linkUserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return provider.AuthCodeURL(stateKey, nonce, verifier), nil
+}
+
+// exchange redeems the code, making sure the state was handed out by the matching Begin call.
+func (s *ExternalAuthService) exchange(providerName, stateKey, code, linkUserID string) (*domain.ExternalIdentity, error) {
+	op := "ports.ExternalAuthService.exchange"
+
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.stateStore.Consume(stateKey)
+	if err != nil {
+		return nil, err
+	}
+	if state.Provider != providerName || state.LinkUserID != linkUserID {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "login expired, try again"}
+	}
+
+	return provider.Exchange(code, state.Verifier, state.Nonce)
+}
+
+// resolve finds or creates the account the identity logs in to.
+func (s *ExternalAuthService) resolve(identity *domain.ExternalIdentity) (*domain.Credential, error) {
+	op := "ports.ExternalAuthService.resolve"
+
+	linked, err := s.identityStore.Find(identity.Provider, identity.Subject)
+	if err == nil {
+		return s.credentialStore.FindById(linked.UserID)
+	}
+	if users.ErrorCode(err) != users.ENOTFOUND {
+		return nil, err
+	}
+
+	credential, err := s.credentialStore.FindByEmail(identity.Email)
+	switch {
+	case err == nil:
+		if !identity.EmailVerified || credential.EmailVerifiedAt == nil {
+			return nil, &users.Error{Op: op, Code: users.ECONFLICT, Message: fmt.Sprintf("an account with email (%s) exists, log in to it to link %s", identity.Email, identity.Provider)}
+		}
+	case users.ErrorCode(err) == users.ENOTFOUND:
+		credential, err = s.signUp(identity)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	identity.UserID = credential.UserID
+	err = s.link(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// signUp creates an account for the identity. It has no password, so the password can only be
+// set with a password reset.
+func (s *ExternalAuthService) signUp(identity *domain.ExternalIdentity) (*domain.Credential, error) {
+	credential, err := s.credentialStore.Insert(&domain.Credential{Email: domain.NormalizeEmail(identity.Email)})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(activityDomain.TypeUserSignedUp, credential.UserID, nil)
+
+	if identity.EmailVerified {
+		credential, err = s.credentialStore.MarkEmailVerified(credential.UserID, credential.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return credential, nil
+}
+
+func (s *ExternalAuthService) link(identity *domain.ExternalIdentity) error {
+	_, err := s.identityStore.Insert(identity)
+	if err != nil {
+		return err
+	}
+
+	s.record(activityDomain.TypeUserIdentityLinked, identity.UserID, map[string]string{"provider": identity.Provider})
+	return nil
+}
+
+func (s *ExternalAuthService) provider(name string) (IdentityProvider, error) {
+	op := "ports.ExternalAuthService.provider"
+
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, &users.Error{Op: op, Code: users.ENOTFOUND, Message: fmt.Sprintf("unknown identity provider (%s)", name)}
+	}
+	return provider, nil
+}
+
+func (s *ExternalAuthService) record(activityType activityDomain.Type, userID string, details map[string]string) {
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityType,
+		SubjectID: userID,
+		Details:   details,
+	})
+}
+
+// randomString returns 256 random bits, encoded to be safe in URLs. That also makes it a valid
+// PKCE code verifier.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
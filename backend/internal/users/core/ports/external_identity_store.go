@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+type ExternalIdentityStore interface {
+	Find(provider, subject string) (*domain.ExternalIdentity, error)
+	// Insert fails with users.ECONFLICT when the identity is linked already, or the user already
+	// has an identity at the provider.
+	Insert(*domain.ExternalIdentity) (*domain.ExternalIdentity, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+// IdentityProvider is an external service users can log in with, using the authorization code
+// flow with PKCE.
+type IdentityProvider interface {
+	// AuthCodeURL returns where to send the user to authenticate. verifier is the PKCE code
+	// verifier; only its challenge is part of the URL.
+	AuthCodeURL(state, nonce, verifier string) string
+	// Exchange redeems the code the provider sent the user back with, and returns the identity it
+	// vouches for. Codes that cannot be redeemed fail with users.EINVALID.
+	Exchange(code, verifier, nonce string) (*domain.ExternalIdentity, error)
+}
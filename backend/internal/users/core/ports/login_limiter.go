@@ -0,0 +1,15 @@
+package ports
+
+// LoginLimiter keeps passwords from being guessed by counting failed logins, per account and per
+// IP address, and locking them out for longer and longer the more they fail.
+type LoginLimiter interface {
+	// Check fails with users.ETOOMANY, telling how long to wait, while the account or the IP
+	// address is locked out.
+	Check(email, ip string) error
+	Fail(email, ip string) error
+	// Succeed forgets the failed logins of the account. Those of the IP address are kept, or
+	// logging into an account of one's own would let anyone keep guessing.
+	Succeed(email string) error
+	// Unlock forgets the failed logins of the account and lifts its lockout.
+	Unlock(email string) error
+}
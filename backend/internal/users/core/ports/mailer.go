@@ -0,0 +1,7 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+
+type Mailer interface {
+	Send(*domain.Mail) error
+}
@@ -0,0 +1,197 @@
+package ports
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/pquerna/otp/totp"
+	"strings"
+)
+
+// recoveryCodeCount is how many recovery codes a user gets when enabling two-factor
+// authentication. Each works once.
+const recoveryCodeCount = 10
+
+type MFAService struct {
+	credentialStore CredentialStore
+	totpStore       TOTPStore
+	activity        activityPorts.Recorder
+	// issuer names the app in authenticator apps.
+	issuer string
+}
+
+func NewMFAService(credentialStore CredentialStore, totpStore TOTPStore, activity activityPorts.Recorder, issuer string) *MFAService {
+	return &MFAService{credentialStore, totpStore, activity, issuer}
+}
+
+// Enroll generates a new TOTP secret for the user. It does not protect anything until Confirm
+// is called with a code generated from it, so enrolling again just replaces the secret.
+func (s *MFAService) Enroll(userID string) (*domain.TOTPEnrollment, error) {
+	op := "ports.MFAService.Enroll"
+
+	credential, err := s.credentialStore.FindById(userID)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.issuer,
+		AccountName: credential.Email,
+	})
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	err = s.totpStore.Save(userID, key.Secret())
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	return &domain.TOTPEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+// Confirm enables two-factor authentication once the user shows their app generates valid codes
+// for the enrolled secret. It returns the recovery codes, which are only ever shown this once.
+func (s *MFAService) Confirm(userID, code string) ([]string, error) {
+	op := "ports.MFAService.Confirm"
+
+	secret, err := s.totpStore.Find(userID)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+	if secret.ConfirmedAt != nil {
+		return nil, &users.Error{Op: op, Code: users.ECONFLICT, Message: "two-factor authentication is already enabled"}
+	}
+	if !totp.Validate(code, secret.Secret) {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "code is incorrect"}
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	err = s.totpStore.Confirm(userID, hashes)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserMFAEnabled, userID)
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off. It takes a code, like logging in does, so a stolen
+// access token is not enough to remove the second factor.
+func (s *MFAService) Disable(userID, code string) error {
+	op := "ports.MFAService.Disable"
+
+	err := s.Verify(userID, code)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.totpStore.Remove(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserMFADisabled, userID)
+	return nil
+}
+
+// Enabled reports whether logging in as the user takes a second factor.
+func (s *MFAService) Enabled(userID string) (bool, error) {
+	op := "ports.MFAService.Enabled"
+
+	secret, err := s.totpStore.Find(userID)
+	if users.ErrorCode(err) == users.ENOTFOUND {
+		return false, nil
+	}
+	if err != nil {
+		return false, &users.Error{Op: op, Err: err}
+	}
+
+	return secret.ConfirmedAt != nil, nil
+}
+
+// Verify checks a code from the user's authenticator app, or one of their recovery codes, which
+// is used up by it.
+func (s *MFAService) Verify(userID, code string) error {
+	op := "ports.MFAService.Verify"
+	msg := "code is incorrect"
+
+	secret, err := s.totpStore.Find(userID)
+	if err != nil {
+		if users.ErrorCode(err) == users.ENOTFOUND {
+			return &users.Error{Op: op, Code: users.EINVALID, Message: "two-factor authentication is not enabled", Err: err}
+		}
+		return &users.Error{Op: op, Err: err}
+	}
+	if secret.ConfirmedAt == nil {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "two-factor authentication is not enabled"}
+	}
+
+	if totp.Validate(code, secret.Secret) {
+		return nil
+	}
+
+	err = s.totpStore.UseRecoveryCode(userID, hashRecoveryCode(code))
+	if err != nil {
+		if users.ErrorCode(err) == users.ENOTFOUND {
+			return &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+		}
+		return &users.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+func (s *MFAService) record(activityType activityDomain.Type, userID string) {
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityType,
+		SubjectID: userID,
+	})
+}
+
+// newRecoveryCodes returns recovery codes, formatted like "abcd-efgh", along with the hashes to
+// store in their place.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+		code = code[:4] + "-" + code[4:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes, which users tend to get wrong when typing a
+// code. Recovery codes are random, so a fast hash is enough.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
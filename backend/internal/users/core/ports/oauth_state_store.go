@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+type OAuthStateStore interface {
+	// Create remembers the state for a limited time and returns the key to it, which is sent to
+	// the provider as the state parameter.
+	Create(*domain.OAuthState) (string, error)
+	// Consume returns the state and forgets it. Unknown, used and expired keys fail with
+	// users.EINVALID.
+	Consume(key string) (*domain.OAuthState, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+// PasswordHasher hashes passwords for storing them. Hashes are self-describing strings that name
+// their algorithm and parameters, so hashes made by other algorithms, or with other parameters,
+// still verify.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the hash. It only fails for hashes it cannot
+	// read.
+	Verify(password, hash string) (bool, error)
+	// NeedsRehash reports whether the hash was made with another algorithm, or weaker
+	// parameters, than Hash uses now.
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,9 @@
+package ports
+
+// PasswordPolicy decides which passwords users may choose.
+type PasswordPolicy interface {
+	// Check fails with users.EINVALID if the password is not allowed, with a reason for every
+	// rule it breaks. personal are things about the user, like their email address and name,
+	// which the password must not contain.
+	Check(password string, personal ...string) error
+}
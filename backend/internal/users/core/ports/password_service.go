@@ -0,0 +1,164 @@
+package ports
+
+import (
+	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"net/url"
+)
+
+type PasswordService struct {
+	credentialStore CredentialStore
+	userStore       UserStore
+	resetStore      ResetStore
+	refreshStore    RefreshStore
+	accessDenylist  AccessDenylist
+	personalTokens  *PersonalAccessTokenService
+	passwordPolicy  PasswordPolicy
+	passwordHasher  PasswordHasher
+	mailer          Mailer
+	activity        activityPorts.Recorder
+	// resetURL is the page of the web app that takes a reset token and asks for a new password.
+	resetURL string
+}
+
+func NewPasswordService(credentialStore CredentialStore, userStore UserStore, resetStore ResetStore, refreshStore RefreshStore, accessDenylist AccessDenylist, personalTokens *PersonalAccessTokenService, passwordPolicy PasswordPolicy, passwordHasher PasswordHasher, mailer Mailer, activity activityPorts.Recorder, resetURL string) *PasswordService {
+	return &PasswordService{credentialStore, userStore, resetStore, refreshStore, accessDenylist, personalTokens, passwordPolicy, passwordHasher, mailer, activity, resetURL}
+}
+
+// Change sets a new password for a user who knows the current one. Access tokens issued before
+// the change are rejected; sessions get new ones by refreshing. Personal access tokens are
+// revoked.
+func (s *PasswordService) Change(userID, currentPassword, newPassword string) error {
+	op := "ports.PasswordService.Change"
+
+	credential, err := s.credentialStore.FindById(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	passwordValid, err := s.passwordHasher.Verify(currentPassword, credential.Password)
+	if err != nil || !passwordValid {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "current password is incorrect", Err: err}
+	}
+
+	err = s.setPassword(credential, newPassword)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.accessDenylist.DenyUser(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.personalTokens.RevokeAll(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserPasswordChanged, userID)
+	return nil
+}
+
+// RequestReset mails a reset link to the address, if it belongs to a user. Unknown addresses
+// are not reported, so the endpoint cannot be used to find out who has an account.
+func (s *PasswordService) RequestReset(email string) error {
+	op := "ports.PasswordService.RequestReset"
+
+	credential, err := s.credentialStore.FindByEmail(email)
+	if users.ErrorCode(err) == users.ENOTFOUND {
+		return nil
+	}
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	token, err := s.resetStore.Create(credential.UserID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.mailer.Send(&domain.Mail{
+		To:      credential.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Someone asked to reset the password of your account. If it was you, "+
+			"choose a new password at %s?token=%s\n\nIf it was not you, you can ignore this mail.", s.resetURL, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserPasswordResetRequested, credential.UserID)
+	return nil
+}
+
+// Reset sets a new password using a token from RequestReset, logs the user out everywhere and
+// revokes their personal access tokens.
+func (s *PasswordService) Reset(token, newPassword string) error {
+	op := "ports.PasswordService.Reset"
+
+	userID, err := s.resetStore.Consume(token)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := s.credentialStore.FindById(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.setPassword(credential, newPassword)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.refreshStore.RevokeAllSessions(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.accessDenylist.DenyUser(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.personalTokens.RevokeAll(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserPasswordReset, userID)
+	return nil
+}
+
+// setPassword makes sure the password follows the password policy before setting it.
+func (s *PasswordService) setPassword(credential *domain.Credential, password string) error {
+	user, err := s.userStore.Get(credential.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.passwordPolicy.Check(password, credential.Email, user.Name)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	credential.Password = hashedPassword
+
+	return s.credentialStore.Update(credential)
+}
+
+func (s *PasswordService) record(activityType activityDomain.Type, userID string) {
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityType,
+		SubjectID: userID,
+	})
+}
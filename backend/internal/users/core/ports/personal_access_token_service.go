@@ -0,0 +1,146 @@
+package ports
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+type PersonalAccessTokenService struct {
+	store    PersonalAccessTokenStore
+	activity activityPorts.Recorder
+}
+
+func NewPersonalAccessTokenService(store PersonalAccessTokenStore, activity activityPorts.Recorder) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{store, activity}
+}
+
+// Create issues a token for the user, limited to scopes. It returns the token itself along with
+// what is stored about it, as the token cannot be looked up later.
+func (s *PersonalAccessTokenService) Create(userID, name string, scopes []string, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error) {
+	op := "ports.PersonalAccessTokenService.Create"
+
+	if len(scopes) == 0 {
+		return nil, "", &users.Error{Op: op, Code: users.EINVALID, Message: "personal access tokens need at least one scope"}
+	}
+	for _, scope := range scopes {
+		if !domain.PersonalAccessTokenScopes[scope] {
+			return nil, "", &users.Error{Op: op, Code: users.EINVALID, Message: fmt.Sprintf("personal access tokens cannot have the %s scope", scope)}
+		}
+	}
+	if expiresAt != nil && expiresAt.Before(time.Now()) {
+		return nil, "", &users.Error{Op: op, Code: users.EINVALID, Message: "expiry must be in the future"}
+	}
+
+	token, err := newPersonalAccessToken()
+	if err != nil {
+		return nil, "", &users.Error{Op: op, Err: err}
+	}
+
+	stored, err := s.store.Insert(&domain.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, hashPersonalAccessToken(token))
+	if err != nil {
+		return nil, "", &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserPersonalAccessTokenCreated, stored)
+
+	return stored, token, nil
+}
+
+func (s *PersonalAccessTokenService) List(userID string) ([]*domain.PersonalAccessToken, error) {
+	op := "ports.PersonalAccessTokenService.List"
+
+	tokens, err := s.store.List(userID)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	return tokens, nil
+}
+
+func (s *PersonalAccessTokenService) Revoke(userID, tokenID string) error {
+	op := "ports.PersonalAccessTokenService.Revoke"
+
+	err := s.store.Delete(userID, tokenID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.record(activityDomain.TypeUserPersonalAccessTokenRevoked, &domain.PersonalAccessToken{TokenID: tokenID, UserID: userID})
+	return nil
+}
+
+// RevokeAll revokes every token of the user. Tokens do not expire on their own, so whatever takes
+// away the sessions of a user who may have lost control of their account revokes them too.
+func (s *PersonalAccessTokenService) RevokeAll(userID string) error {
+	op := "ports.PersonalAccessTokenService.RevokeAll"
+
+	err := s.store.DeleteAll(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+// Authenticate returns the claims of a personal access token, as if it was an access token.
+// Unknown, revoked and expired tokens fail with users.EINVALID.
+func (s *PersonalAccessTokenService) Authenticate(token string) (*domain.AccessClaims, error) {
+	op := "ports.PersonalAccessTokenService.Authenticate"
+
+	stored, err := s.store.Use(hashPersonalAccessToken(token))
+	if users.ErrorCode(err) == users.ENOTFOUND {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "access token is invalid", Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	claims := &domain.AccessClaims{
+		UserID:   stored.UserID,
+		TokenID:  stored.TokenID,
+		Scopes:   stored.Scopes,
+		IssuedAt: stored.CreatedAt,
+	}
+	if stored.ExpiresAt != nil {
+		claims.ExpiresAt = *stored.ExpiresAt
+	}
+	return claims, nil
+}
+
+func (s *PersonalAccessTokenService) record(activityType activityDomain.Type, token *domain.PersonalAccessToken) {
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   token.UserID,
+		Type:      activityType,
+		SubjectID: token.UserID,
+		Details:   map[string]string{"token_id": token.TokenID},
+	})
+}
+
+// newPersonalAccessToken returns 256 random bits, encoded to be safe in URLs and headers.
+func newPersonalAccessToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return domain.PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashPersonalAccessToken hashes tokens for storing them. Tokens are random, so a fast hash is
+// enough.
+func hashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,17 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+
+// PersonalAccessTokenStore keeps personal access tokens by a hash of the token, so reading the
+// store is not enough to act as its users.
+type PersonalAccessTokenStore interface {
+	Insert(token *domain.PersonalAccessToken, tokenHash string) (*domain.PersonalAccessToken, error)
+	// List returns the user's tokens, newest first, including expired ones.
+	List(userID string) ([]*domain.PersonalAccessToken, error)
+	// Delete fails with users.ENOTFOUND if the token is not one of the user's.
+	Delete(userID, tokenID string) error
+	DeleteAll(userID string) error
+	// Use returns the token with the hash and records that it was just used. Unknown and expired
+	// tokens fail with users.ENOTFOUND.
+	Use(tokenHash string) (*domain.PersonalAccessToken, error)
+}
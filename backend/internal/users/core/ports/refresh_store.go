@@ -2,8 +2,21 @@ package ports
 
 import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
 
+// RefreshStore issues refresh tokens in families, one family per session: logging in starts a
+// family, and every refresh replaces the family's token with a new one. Only the latest token of
+// a family is valid.
 type RefreshStore interface {
-	Create(*domain.Credential) (*domain.RefreshToken, error)
-	Revoke(*domain.RefreshToken) error
-	Verify(*domain.RefreshToken) (*domain.Credential, error)
+	// Create starts a new session for the user and returns its first token.
+	Create(userID string, client *domain.Client) (*domain.Session, *domain.RefreshToken, error)
+	// Rotate exchanges the latest token of a family for a new one. Presenting a token that was
+	// already rotated means it leaked, so the whole family is revoked, along with the access
+	// tokens of the session, and Rotate fails with users.EINVALID.
+	Rotate(*domain.RefreshToken, *domain.Client) (*domain.Session, *domain.RefreshToken, error)
+	// Revoke ends the session the token belongs to. It fails with users.ENOTFOUND if the token is
+	// not one of the user's.
+	Revoke(userID string, token *domain.RefreshToken) error
+	ListSessions(userID string) ([]*domain.Session, error)
+	// RevokeSession fails with users.ENOTFOUND if the session is not one of the user's.
+	RevokeSession(userID, sessionID string) error
+	RevokeAllSessions(userID string) error
 }
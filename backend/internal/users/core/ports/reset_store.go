@@ -0,0 +1,9 @@
+package ports
+
+type ResetStore interface {
+	// Create issues a password reset token for the user, valid for a limited time.
+	Create(userID string) (string, error)
+	// Consume returns the user the token was issued for and invalidates it, so every token works
+	// at most once. Unknown, used and expired tokens fail with users.ENOTFOUND.
+	Consume(token string) (string, error)
+}
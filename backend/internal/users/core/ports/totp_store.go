@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+type TOTPStore interface {
+	// Save sets a new unconfirmed secret for the user. It fails with users.ECONFLICT when the user
+	// already has a confirmed one.
+	Save(userID, secret string) error
+	Find(userID string) (*domain.TOTP, error)
+	// Confirm turns the pending secret into a second factor and replaces the user's recovery
+	// codes. It fails with users.ENOTFOUND when there is no pending secret.
+	Confirm(userID string, recoveryCodeHashes []string) error
+	// Remove drops the secret and the recovery codes.
+	Remove(userID string) error
+	// UseRecoveryCode invalidates the code, failing with users.ENOTFOUND when the user has no such
+	// code.
+	UseRecoveryCode(userID, recoveryCodeHash string) error
+}
@@ -13,15 +13,17 @@ func NewUserService(store UserStore) *UserService {
 	return &UserService{store}
 }
 
-func (s *UserService) Update(user *domain.User) error {
+// Update overwrites the user's metadata if user.Version is the stored version, failing with
+// ECONFLICT when it is stale.
+func (s *UserService) Update(user *domain.User) (*domain.User, error) {
 	op := "ports.UserService.Update"
 
-	_, err := s.store.Update(user)
+	updated, err := s.store.Update(user)
 	if err != nil {
-		return &users.Error{Op: op, Err: err}
+		return nil, &users.Error{Op: op, Err: err}
 	}
 
-	return nil
+	return updated, nil
 }
 
 func (s *UserService) Find(userId string) (*domain.User, error) {
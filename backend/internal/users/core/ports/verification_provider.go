@@ -0,0 +1,9 @@
+package ports
+
+type VerificationProvider interface {
+	// Create returns a token proving that whoever holds it received mail sent to email.
+	Create(userID, email string) (string, error)
+	// Verify returns the user and address the token was created for. Tampered tokens fail with
+	// users.EINVALID and expired ones with users.EEXPIRED.
+	Verify(token string) (userID, email string, err error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+
+// VerificationSender mails the first verification link to users who just signed up.
+type VerificationSender interface {
+	// Send is best effort: the account is already created, and a user who got no link can ask for
+	// another one.
+	Send(*domain.Credential)
+}
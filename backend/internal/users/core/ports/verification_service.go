@@ -0,0 +1,98 @@
+package ports
+
+import (
+	"fmt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	activityPorts "github.com/hardiksachan/kanban_board/backend/internal/activity/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"net/url"
+)
+
+type VerificationService struct {
+	credentialStore CredentialStore
+	provider        VerificationProvider
+	mailer          Mailer
+	activity        activityPorts.Recorder
+	// verifyURL is the page of the web app that takes a verification token and confirms it.
+	verifyURL string
+}
+
+func NewVerificationService(credentialStore CredentialStore, provider VerificationProvider, mailer Mailer, activity activityPorts.Recorder, verifyURL string) *VerificationService {
+	return &VerificationService{credentialStore, provider, mailer, activity, verifyURL}
+}
+
+// Send mails a verification link to the credential's address.
+func (s *VerificationService) Send(credential *domain.Credential) error {
+	op := "ports.VerificationService.Send"
+
+	token, err := s.provider.Create(credential.UserID, credential.Email)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.mailer.Send(&domain.Mail{
+		To:      credential.Email,
+		Subject: "Verify your email address",
+		Body:    fmt.Sprintf("Confirm that this is your address by opening %s?token=%s", s.verifyURL, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+// Resend mails a new verification link to the address, if it belongs to an unverified account.
+// Nothing is reported otherwise, so the endpoint cannot be used to find out who has an account.
+func (s *VerificationService) Resend(email string) error {
+	op := "ports.VerificationService.Resend"
+
+	credential, err := s.credentialStore.FindByEmail(email)
+	if users.ErrorCode(err) == users.ENOTFOUND {
+		return nil
+	}
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	if credential.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = s.Send(credential)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+// Verify marks the address in the token as verified. Tokens for an address the user no longer
+// has are rejected; verifying an address twice is fine.
+func (s *VerificationService) Verify(token string) error {
+	op := "ports.VerificationService.Verify"
+
+	userID, email, err := s.provider.Verify(token)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	credential, err := s.credentialStore.FindById(userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	if credential.EmailVerifiedAt != nil && credential.Email == email {
+		return nil
+	}
+
+	_, err = s.credentialStore.MarkEmailVerified(userID, email)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	s.activity.Record(&activityDomain.Activity{
+		ActorID:   userID,
+		Type:      activityDomain.TypeUserEmailVerified,
+		SubjectID: userID,
+	})
+	return nil
+}
@@ -3,20 +3,27 @@ package users
 import (
 	"bytes"
 	"fmt"
+	"time"
 )
 
 // Application error codes.
 const (
-	ECONFLICT = "conflict"  // action cannot be performed
-	EINTERNAL = "internal"  // internal error
-	EINVALID  = "invalid"   // validation failed
-	ENOTFOUND = "not_found" // entity does not exist
-	EEXPIRED  = "expired"   // entity is expired
+	ECONFLICT  = "conflict"  // action cannot be performed
+	EINTERNAL  = "internal"  // internal error
+	EINVALID   = "invalid"   // validation failed
+	ENOTFOUND  = "not_found" // entity does not exist
+	EEXPIRED   = "expired"   // entity is expired
+	EFORBIDDEN = "forbidden" // actor lacks the required permission
+	ETOOMANY   = "too_many"  // too many attempts, retry later
 )
 
 type Error struct {
 	// Machine Readable
 	Code string
+	// RetryAfter is how long to wait before retrying, for ETOOMANY errors.
+	RetryAfter time.Duration
+	// Reasons tell everything that is wrong with the input, for EINVALID errors.
+	Reasons []Reason
 
 	// Human Readable
 	Message string
@@ -26,6 +33,15 @@ type Error struct {
 	Err error
 }
 
+// Reason is one of the things wrong with the input, for clients to display or act on.
+type Reason struct {
+	// Machine Readable
+	Code string
+
+	// Human Readable
+	Message string
+}
+
 // ErrorCode returns the code of the root error, if available. Otherwise, returns EINTERNAL.
 func ErrorCode(err error) string {
 	if err == nil {
@@ -51,6 +67,31 @@ func ErrorMessage(err error) string {
 	return "An internal error has occurred. Please contact technical support."
 }
 
+// ErrorRetryAfter returns how long the root error asks to wait before retrying, if available.
+// Otherwise, returns 0.
+func ErrorRetryAfter(err error) time.Duration {
+	if err == nil {
+		return 0
+	} else if e, ok := err.(*Error); ok && e.RetryAfter != 0 {
+		return e.RetryAfter
+	} else if ok && e.Err != nil {
+		return ErrorRetryAfter(e.Err)
+	}
+	return 0
+}
+
+// ErrorReasons returns the reasons of the root error, if available. Otherwise, returns nil.
+func ErrorReasons(err error) []Reason {
+	if err == nil {
+		return nil
+	} else if e, ok := err.(*Error); ok && len(e.Reasons) != 0 {
+		return e.Reasons
+	} else if ok && e.Err != nil {
+		return ErrorReasons(e.Err)
+	}
+	return nil
+}
+
 // Error returns the string representation of the error message.
 func (e *Error) Error() string {
 	var buf bytes.Buffer
@@ -0,0 +1,137 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	account  *ports.AccountService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewAccountHandler(account *ports.AccountService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{account, log, validator}
+}
+
+// Delete answers 202, as the account is only purged once the grace period is over.
+func (h *Handler) Delete(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	rUserID := mux.Vars(r)["user_id"]
+
+	if loggedInUserID != rUserID {
+		h.log.Debug(fmt.Sprintf("user %s cannot delete user %s", loggedInUserID, rUserID))
+
+		http.Error(rw, "cannot delete a different user", http.StatusForbidden)
+		return
+	}
+
+	purgeAt, err := h.account.Delete(rUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("account scheduled for deletion. userId: %s, purgeAt: %s", rUserID, purgeAt))
+	rw.WriteHeader(http.StatusAccepted)
+	h.writeJSON(rw, &DeleteResponse{PurgeAt: purgeAt})
+}
+
+func (h *Handler) Restore(rw http.ResponseWriter, r *http.Request) {
+	rm, err := jsonHelper.Parse[RestoreRequest](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return
+	}
+
+	err = h.account.Restore(rm.Email, rm.Password, auth.ClientFrom(r, "").IP)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug("account restored successfully")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// Lock keeps the user in the route from logging in, for admins dealing with accounts that were
+// taken over.
+func (h *Handler) Lock(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	rUserID := mux.Vars(r)["user_id"]
+
+	err := h.account.Lock(loggedInUserID, rUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("account locked. userId: %s, adminId: %s", rUserID, loggedInUserID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// Unlock lifts the lockout of the user in the route, for admins helping users who got locked out.
+func (h *Handler) Unlock(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	rUserID := mux.Vars(r)["user_id"]
+
+	err := h.account.Unlock(loggedInUserID, rUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("account unlocked. userId: %s, adminId: %s", rUserID, loggedInUserID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+	case users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+	case users.EFORBIDDEN:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusForbidden)
+	case users.ETOOMANY:
+		h.log.Debug(err.Error())
+		retryAfter := math.Ceil(users.ErrorRetryAfter(err).Seconds())
+		rw.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+		http.Error(rw, users.ErrorMessage(err), http.StatusTooManyRequests)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
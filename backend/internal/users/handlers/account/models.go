@@ -0,0 +1,12 @@
+package account
+
+import "time"
+
+type DeleteResponse struct {
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+type RestoreRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
@@ -10,7 +10,11 @@ import (
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
 	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
 	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type CredentialKey struct {
@@ -19,6 +23,17 @@ type CredentialKey struct {
 type UserIDKey struct {
 }
 
+type SessionIDKey struct {
+}
+
+// AccessClaimsKey holds the claims of the access token the request was made with.
+type AccessClaimsKey struct {
+}
+
+// RolesKey holds the user's app wide roles.
+type RolesKey struct {
+}
+
 type Handler struct {
 	auth      *ports.AuthService
 	log       logging.Logger
@@ -49,12 +64,15 @@ func (h *Handler) SignUp(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	// call application layer to SignUp user
-	signedUpUser, err := h.auth.SignUp(rm.toDomain())
+	signedUpUser, err := h.auth.SignUp(rm.toDomain(), rm.Name)
 	if err != nil {
 		switch users.ErrorCode(err) {
 		case users.ECONFLICT:
 			h.log.Debug(err.Error())
 			http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+		case users.EINVALID:
+			h.log.Debug(err.Error())
+			WriteInvalid(rw, err)
 		default:
 			h.log.Warn(err.Error())
 			http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
@@ -86,12 +104,20 @@ func (h *Handler) LogIn(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	// call application layer to Log In user
-	storedUser, refreshToken, accessToken, err := h.auth.LogIn(rm.Email, rm.Password)
+	storedUser, challenge, refreshToken, accessToken, err := h.auth.LogIn(rm.Email, rm.Password, ClientFrom(r, rm.Device))
 	if err != nil {
 		switch users.ErrorCode(err) {
 		case users.ECONFLICT, users.ENOTFOUND:
 			h.log.Debug(err.Error())
 			http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+		case users.EFORBIDDEN:
+			h.log.Debug(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusForbidden)
+		case users.ETOOMANY:
+			h.log.Debug(err.Error())
+			retryAfter := math.Ceil(users.ErrorRetryAfter(err).Seconds())
+			rw.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+			http.Error(rw, users.ErrorMessage(err), http.StatusTooManyRequests)
 		default:
 			h.log.Warn(err.Error())
 			http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
@@ -99,7 +125,67 @@ func (h *Handler) LogIn(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.log.Debug(fmt.Sprintf("user logged in successfully. accessToken: %s, refreshToken: %s", accessToken, refreshToken))
+	if challenge != nil {
+		h.log.Debug(fmt.Sprintf("second factor required. userId: %s", storedUser.UserID))
+
+		json.NewEncoder(rw).Encode(MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    string(*challenge),
+		})
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("user logged in successfully. accessToken: %s, refreshToken: %s", *accessToken, *refreshToken))
+
+	json.NewEncoder(rw).Encode(LogInResponse{
+		AccessToken:  string(*accessToken),
+		RefreshToken: string(*refreshToken),
+		UserId:       storedUser.UserID,
+	})
+}
+
+// LogInMFA finishes a login that needs a second factor.
+func (h *Handler) LogInMFA(rw http.ResponseWriter, r *http.Request) {
+	rm, err := jsonHelper.Parse[LogInMFARequest](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return
+	}
+
+	// validate and sanitize input
+	validationErr := h.validator.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return
+	}
+
+	challenge := (domain.MFAChallenge)(rm.MFAToken)
+	storedUser, refreshToken, accessToken, err := h.auth.VerifyMFA(&challenge, rm.Code, ClientFrom(r, rm.Device))
+	if err != nil {
+		switch users.ErrorCode(err) {
+		case users.EINVALID:
+			h.log.Debug(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusUnauthorized)
+		case users.EFORBIDDEN:
+			h.log.Debug(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusForbidden)
+		case users.ETOOMANY:
+			h.log.Debug(err.Error())
+			retryAfter := math.Ceil(users.ErrorRetryAfter(err).Seconds())
+			rw.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+			http.Error(rw, users.ErrorMessage(err), http.StatusTooManyRequests)
+		default:
+			h.log.Warn(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("user logged in successfully. accessToken: %s, refreshToken: %s", *accessToken, *refreshToken))
 
 	json.NewEncoder(rw).Encode(LogInResponse{
 		AccessToken:  string(*accessToken),
@@ -109,6 +195,8 @@ func (h *Handler) LogIn(rw http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) LogOut(rw http.ResponseWriter, r *http.Request) {
+	accessClaims := r.Context().Value(&AccessClaimsKey{}).(*domain.AccessClaims)
+
 	rm, err := jsonHelper.Parse[LogOutRequest](r.Body)
 	if err != nil {
 		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
@@ -119,10 +207,16 @@ func (h *Handler) LogOut(rw http.ResponseWriter, r *http.Request) {
 
 	refreshToken := (domain.RefreshToken)(rm.RefreshToken)
 
-	err = h.auth.LogOut(&refreshToken)
+	err = h.auth.LogOut(accessClaims, &refreshToken)
 	if err != nil {
-		h.log.Warn(err.Error())
-		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+		switch users.ErrorCode(err) {
+		case users.ENOTFOUND, users.EEXPIRED:
+			h.log.Debug(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+		default:
+			h.log.Warn(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -140,22 +234,41 @@ func (h *Handler) RefreshAccessToken(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	refreshToken := (domain.RefreshToken)(rm.RefreshToken)
-	accessToken, err := h.auth.RegenerateAccessToken(&refreshToken)
+	rotatedToken, accessToken, err := h.auth.Refresh(&refreshToken, ClientFrom(r, ""))
 	if err != nil {
-		h.log.Warn(err.Error())
-		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+		switch users.ErrorCode(err) {
+		case users.EINVALID, users.ENOTFOUND, users.EEXPIRED:
+			h.log.Debug(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusUnauthorized)
+		default:
+			h.log.Warn(err.Error())
+			http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+		}
 		return
 	}
 
-	h.log.Debug(fmt.Sprintf("access token generated successfully. accessToken: %s", accessToken))
+	h.log.Debug(fmt.Sprintf("access token generated successfully. accessToken: %s, refreshToken: %s", *accessToken, *rotatedToken))
 	json.NewEncoder(rw).Encode(&RefreshAccessTokenResponse{
-		AccessToken: string(*accessToken),
+		AccessToken:  string(*accessToken),
+		RefreshToken: string(*rotatedToken),
 	})
 }
 
+// JWKS publishes the keys that verify access tokens. Keys are cached for a few minutes, so a new
+// key has to be published that long before it starts signing.
+func (h *Handler) JWKS(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "public, max-age=300")
+
+	err := json.NewEncoder(rw).Encode(toJSONWebKeySet(h.auth.PublicKeys()))
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
+
 func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		accessTokenStr := r.Header.Get("Authorization")
+		accessTokenStr := accessTokenFrom(r)
 		if accessTokenStr == "" {
 			h.log.Debug(fmt.Sprintf("access token not provided"))
 
@@ -165,7 +278,7 @@ func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 
 		accessToken := (domain.AccessToken)(accessTokenStr)
 
-		credential, err := h.auth.DecodeAccessToken(&accessToken)
+		credential, accessClaims, err := h.auth.DecodeAccessToken(&accessToken)
 		if err != nil {
 			switch users.ErrorCode(err) {
 			case users.EINVALID:
@@ -184,7 +297,71 @@ func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, &CredentialKey{}, credential)
 		ctx = context.WithValue(ctx, &UserIDKey{}, credential.UserID)
+		ctx = context.WithValue(ctx, &SessionIDKey{}, accessClaims.SessionID)
+		ctx = context.WithValue(ctx, &AccessClaimsKey{}, accessClaims)
+		ctx = context.WithValue(ctx, &RolesKey{}, accessClaims.Roles)
 
 		next.ServeHTTP(rw, r.WithContext(ctx))
 	})
 }
+
+// RequireScope only lets requests through whose access token has scope. It goes after
+// AuthMiddleware.
+func (h *Handler) RequireScope(scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		accessClaims, _ := r.Context().Value(&AccessClaimsKey{}).(*domain.AccessClaims)
+		if accessClaims == nil || !accessClaims.HasScope(scope) {
+			h.log.Debug(fmt.Sprintf("access token lacks scope %s", scope))
+
+			http.Error(rw, fmt.Sprintf("access token lacks the %s scope", scope), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// WriteInvalid answers 400 for an EINVALID error. Errors with reasons are written as JSON, for
+// clients to show everything the user has to fix at once.
+func WriteInvalid(rw http.ResponseWriter, err error) {
+	if len(users.ErrorReasons(err)) == 0 {
+		http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(rw).Encode(toInvalidResponse(err))
+}
+
+// ClientFrom describes the client making the request. X-Forwarded-For is not trusted, as nothing
+// in front of the server guarantees it was set by a proxy.
+func ClientFrom(r *http.Request, device string) *domain.Client {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return &domain.Client{
+		Device:    device,
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+	}
+}
+
+// accessTokenFrom reads the access token from the Authorization header. Browsers cannot set
+// headers on WebSocket handshakes or EventSource requests, so those may pass it as the
+// access_token query parameter instead.
+func accessTokenFrom(r *http.Request) string {
+	accessTokenStr := r.Header.Get("Authorization")
+	if accessTokenStr != "" {
+		return accessTokenStr
+	}
+
+	isWebSocket := strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+	isEventStream := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	if isWebSocket || isEventStream {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
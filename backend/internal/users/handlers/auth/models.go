@@ -1,18 +1,26 @@
 package auth
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"math/big"
 )
 
 type SignUpRequest struct {
-	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	// Password is checked against the password policy, which reports everything wrong with it.
+	Password string `json:"password" validate:"required"`
 }
 
 type LogInRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" validate:"required"`
+	// Device names the session in the session list, e.g. "Work laptop".
+	Device string `json:"device,omitempty" validate:"max=100"`
 }
 
 type LogInResponse struct {
@@ -21,6 +29,20 @@ type LogInResponse struct {
 	UserId       string `json:"user_id,omitempty"`
 }
 
+// MFAChallengeResponse answers a login that needs a second factor. MFAToken is sent to the MFA
+// login endpoint along with the code.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+type LogInMFARequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	// Code is a code from the user's authenticator app, or one of their recovery codes.
+	Code   string `json:"code" validate:"required,max=20"`
+	Device string `json:"device,omitempty" validate:"max=100"`
+}
+
 type LogOutRequest struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
 }
@@ -31,6 +53,8 @@ type RefreshAccessTokenRequest struct {
 
 type RefreshAccessTokenResponse struct {
 	AccessToken string `json:"access_token"`
+	// RefreshToken replaces the refresh token of the request, which is no longer valid.
+	RefreshToken string `json:"refresh_token"`
 }
 
 func (r *SignUpRequest) toDomain() *domain.Credential {
@@ -40,3 +64,69 @@ func (r *SignUpRequest) toDomain() *domain.Credential {
 		Password: r.Password,
 	}
 }
+
+// InvalidResponse tells what is wrong with the request, with a reason for every problem found.
+type InvalidResponse struct {
+	Message string            `json:"message"`
+	Reasons []*ReasonResponse `json:"reasons,omitempty"`
+}
+
+type ReasonResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func toInvalidResponse(err error) *InvalidResponse {
+	response := &InvalidResponse{Message: users.ErrorMessage(err)}
+	for _, reason := range users.ErrorReasons(err) {
+		response.Reasons = append(response.Reasons, &ReasonResponse{Code: reason.Code, Message: reason.Message})
+	}
+	return response
+}
+
+// JSONWebKeySet is the JWKS document other services verify access tokens with (RFC 7517).
+type JSONWebKeySet struct {
+	Keys []*JSONWebKey `json:"keys"`
+}
+
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// N and E are set for RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve and X are set for Ed25519 keys.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+func toJSONWebKeySet(publicKeys []*domain.PublicKey) *JSONWebKeySet {
+	keySet := &JSONWebKeySet{Keys: make([]*JSONWebKey, 0, len(publicKeys))}
+
+	for _, publicKey := range publicKeys {
+		jwk := &JSONWebKey{
+			KeyID:     publicKey.KeyID,
+			Use:       "sig",
+			Algorithm: publicKey.Algorithm,
+		}
+
+		switch key := publicKey.Key.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		default:
+			continue
+		}
+
+		keySet.Keys = append(keySet.Keys, jwk)
+	}
+
+	return keySet
+}
@@ -0,0 +1,92 @@
+package email
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	email    *ports.EmailService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewEmailHandler(email *ports.EmailService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{email, log, validator}
+}
+
+// Change answers 202, as the address only changes once the user confirms it.
+func (h *Handler) Change(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	rm, ok := parseRequest[ChangeRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.email.RequestChange(loggedInUserID, rm.Email)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("email change requested. userId: %s", loggedInUserID))
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) Confirm(rw http.ResponseWriter, r *http.Request) {
+	rm, ok := parseRequest[ConfirmRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.email.ConfirmChange(rm.Token)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug("email changed successfully")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID, users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+	case users.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusConflict)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,9 @@
+package email
+
+type ChangeRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,117 @@
+package mfa
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	mfa      *ports.MFAService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewMFAHandler(mfa *ports.MFAService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{mfa, log, validator}
+}
+
+func (h *Handler) Enroll(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	enrollment, err := h.mfa.Enroll(loggedInUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("totp enrollment started. userId: %s", loggedInUserID))
+	h.writeJSON(rw, toEnrollResponse(enrollment))
+}
+
+func (h *Handler) Confirm(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	rm, ok := parseRequest[CodeRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.mfa.Confirm(loggedInUserID, rm.Code)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("two-factor authentication enabled. userId: %s", loggedInUserID))
+	h.writeJSON(rw, &ConfirmResponse{RecoveryCodes: recoveryCodes})
+}
+
+func (h *Handler) Disable(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	rm, ok := parseRequest[CodeRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.mfa.Disable(loggedInUserID, rm.Code)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("two-factor authentication disabled. userId: %s", loggedInUserID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+	case users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+	case users.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusConflict)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
@@ -0,0 +1,27 @@
+package mfa
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+)
+
+type EnrollResponse struct {
+	Secret string `json:"secret"`
+	// OtpauthURI is usually shown as a QR code for authenticator apps to scan.
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+type CodeRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type ConfirmResponse struct {
+	// RecoveryCodes each log in once without the authenticator app. They are not shown again.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func toEnrollResponse(enrollment *domain.TOTPEnrollment) *EnrollResponse {
+	return &EnrollResponse{
+		Secret:     enrollment.Secret,
+		OtpauthURI: enrollment.URI,
+	}
+}
@@ -0,0 +1,153 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+// Handler serves logins through identity providers. The provider sends users back to the web
+// app, which passes the code and state on to the callback endpoints, so tokens never show up in
+// a URL.
+type Handler struct {
+	external *ports.ExternalAuthService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewOAuthHandler(external *ports.ExternalAuthService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{external, log, validator}
+}
+
+func (h *Handler) Authorize(rw http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+
+	authURL, err := h.external.Begin(provider)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("external login started. provider: %s", provider))
+	h.writeJSON(rw, &AuthorizationResponse{AuthorizationURL: authURL})
+}
+
+// Callback answers like a login does: with tokens, or with a challenge for the second factor.
+func (h *Handler) Callback(rw http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+
+	rm, ok := parseRequest[CallbackRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	storedUser, challenge, refreshToken, accessToken, err := h.external.Complete(provider, rm.State, rm.Code, auth.ClientFrom(r, rm.Device))
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	if challenge != nil {
+		h.log.Debug(fmt.Sprintf("second factor required. userId: %s", storedUser.UserID))
+		h.writeJSON(rw, &auth.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    string(*challenge),
+		})
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("user logged in with %s successfully. userId: %s", provider, storedUser.UserID))
+	h.writeJSON(rw, &auth.LogInResponse{
+		AccessToken:  string(*accessToken),
+		RefreshToken: string(*refreshToken),
+		UserId:       storedUser.UserID,
+	})
+}
+
+func (h *Handler) AuthorizeLink(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	provider := mux.Vars(r)["provider"]
+
+	authURL, err := h.external.BeginLink(provider, loggedInUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("identity linking started. userId: %s, provider: %s", loggedInUserID, provider))
+	h.writeJSON(rw, &AuthorizationResponse{AuthorizationURL: authURL})
+}
+
+func (h *Handler) LinkCallback(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	provider := mux.Vars(r)["provider"]
+
+	rm, ok := parseRequest[CallbackRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.external.CompleteLink(provider, loggedInUserID, rm.State, rm.Code)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("identity linked successfully. userId: %s, provider: %s", loggedInUserID, provider))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusUnauthorized)
+	case users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+	case users.ECONFLICT:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusConflict)
+	case users.EFORBIDDEN:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusForbidden)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(rw http.ResponseWriter, v interface{}) {
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
@@ -0,0 +1,13 @@
+package oauth
+
+type AuthorizationResponse struct {
+	// AuthorizationURL is where to send the user to log in at the identity provider.
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// CallbackRequest carries what the identity provider sent the user back to the app with.
+type CallbackRequest struct {
+	Code   string `json:"code" validate:"required"`
+	State  string `json:"state" validate:"required"`
+	Device string `json:"device,omitempty" validate:"max=100"`
+}
@@ -0,0 +1,108 @@
+package password
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	password *ports.PasswordService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewPasswordHandler(password *ports.PasswordService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{password, log, validator}
+}
+
+func (h *Handler) Change(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	rm, ok := parseRequest[ChangeRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.password.Change(loggedInUserID, rm.CurrentPassword, rm.NewPassword)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("password changed successfully. userId: %s", loggedInUserID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// Forgot always answers 202, whether or not the address belongs to an account.
+func (h *Handler) Forgot(rw http.ResponseWriter, r *http.Request) {
+	rm, ok := parseRequest[ForgotRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.password.RequestReset(rm.Email)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug("password reset requested")
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) Reset(rw http.ResponseWriter, r *http.Request) {
+	rm, ok := parseRequest[ResetRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.password.Reset(rm.Token, rm.NewPassword)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug("password reset successfully")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID:
+		h.log.Debug(err.Error())
+		auth.WriteInvalid(rw, err)
+	case users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,15 @@
+package password
+
+type ChangeRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+type ForgotRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
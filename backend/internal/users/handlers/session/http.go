@@ -0,0 +1,82 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	auth *ports.AuthService
+	log  logging.Logger
+}
+
+func NewSessionHandler(auth *ports.AuthService, log logging.Logger) *Handler {
+	return &Handler{auth, log}
+}
+
+func (h *Handler) List(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	currentSessionID := r.Context().Value(&auth.SessionIDKey{}).(string)
+
+	sessions, err := h.auth.ListSessions(loggedInUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	response := make([]*SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, toSessionResponse(session, currentSessionID))
+	}
+
+	h.log.Debug(fmt.Sprintf("sessions fetch succesfull. userId: %s", loggedInUserID))
+	err = json.NewEncoder(rw).Encode(response)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
+
+func (h *Handler) Revoke(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	sessionID := mux.Vars(r)["session_id"]
+
+	err := h.auth.RevokeSession(loggedInUserID, sessionID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("session revoked successfully. userId: %s, sessionId: %s", loggedInUserID, sessionID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAll logs the user out everywhere, including the session making the request.
+func (h *Handler) RevokeAll(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	err := h.auth.RevokeAllSessions(loggedInUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("all sessions revoked successfully. userId: %s", loggedInUserID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+type SessionResponse struct {
+	SessionID  string    `json:"session_id"`
+	Device     string    `json:"device,omitempty"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+func toSessionResponse(session *domain.Session, currentSessionID string) *SessionResponse {
+	return &SessionResponse{
+		SessionID:  session.SessionID,
+		Device:     session.Client.Device,
+		IP:         session.Client.IP,
+		UserAgent:  session.Client.UserAgent,
+		Current:    session.SessionID == currentSessionID,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+	}
+}
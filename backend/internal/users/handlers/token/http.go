@@ -0,0 +1,117 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	tokens   *ports.PersonalAccessTokenService
+	log      logging.Logger
+	validate *validator.Validate
+}
+
+func NewTokenHandler(tokens *ports.PersonalAccessTokenService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{tokens, log, validator}
+}
+
+// Create answers with the token itself, which cannot be shown again.
+func (h *Handler) Create(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	rm, ok := parseRequest[CreateRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	stored, token, err := h.tokens.Create(loggedInUserID, rm.Name, rm.Scopes, rm.ExpiresAt)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("personal access token created. userId: %s, tokenId: %s", loggedInUserID, stored.TokenID))
+	rw.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(rw).Encode(&CreateResponse{TokenResponse: toTokenResponse(stored), Token: token})
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
+
+func (h *Handler) List(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+
+	tokens, err := h.tokens.List(loggedInUserID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	response := make([]*TokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, toTokenResponse(token))
+	}
+
+	h.log.Debug(fmt.Sprintf("personal access tokens fetch succesfull. userId: %s", loggedInUserID))
+	err = json.NewEncoder(rw).Encode(response)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to marshall response. err: %s", err.Error()))
+	}
+}
+
+func (h *Handler) Revoke(rw http.ResponseWriter, r *http.Request) {
+	loggedInUserID := r.Context().Value(&auth.UserIDKey{}).(string)
+	tokenID := mux.Vars(r)["token_id"]
+
+	err := h.tokens.Revoke(loggedInUserID, tokenID)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug(fmt.Sprintf("personal access token revoked. userId: %s, tokenId: %s", loggedInUserID, tokenID))
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+	case users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
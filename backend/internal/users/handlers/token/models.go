@@ -0,0 +1,39 @@
+package token
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+type CreateRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1"`
+	// ExpiresAt is left out for tokens that work until they are revoked.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type TokenResponse struct {
+	TokenID    string     `json:"token_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// CreateResponse is the only response the token itself is part of.
+type CreateResponse struct {
+	*TokenResponse
+	Token string `json:"token"`
+}
+
+func toTokenResponse(token *domain.PersonalAccessToken) *TokenResponse {
+	return &TokenResponse{
+		TokenID:    token.TokenID,
+		Name:       token.Name,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+	}
+}
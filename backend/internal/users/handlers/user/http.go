@@ -9,6 +9,7 @@ import (
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/handlers/auth"
+	"github.com/hardiksachan/kanban_board/backend/shared/etag"
 	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
 	"github.com/hardiksachan/kanban_board/backend/shared/logging"
 	"net/http"
@@ -52,19 +53,39 @@ func (h *Handler) Update(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.user.Update(&domain.User{
+	version, ok := etag.RequireIfMatch(rw, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.user.Update(&domain.User{
 		UserID:   rUserID,
 		Name:     rm.Name,
 		ImageURL: rm.ProfileURL,
+		Version:  version,
 	})
 	if err != nil {
-		h.log.Debug(fmt.Sprintf("unable to update metadata. err: %s", err.Error()))
+		switch users.ErrorCode(err) {
+		case users.ECONFLICT:
+			h.log.Debug(fmt.Sprintf("stale metadata update. err: %s", err.Error()))
 
-		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
-		return
+			etag.WritePreconditionFailed(rw, users.ErrorMessage(err))
+			return
+		case users.ENOTFOUND:
+			h.log.Debug(fmt.Sprintf("invalid user. err: %s", err.Error()))
+
+			http.Error(rw, users.ErrorMessage(err), http.StatusNotFound)
+			return
+		default:
+			h.log.Debug(fmt.Sprintf("unable to update metadata. err: %s", err.Error()))
+
+			http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+			return
+		}
 	}
 
 	h.log.Debug(fmt.Sprintf("metadata update succesfull. userId: %s", rUserID))
+	rw.Header().Set("ETag", etag.Format(updated.Version))
 	rw.WriteHeader(http.StatusCreated)
 }
 
@@ -88,7 +109,8 @@ func (h *Handler) Get(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	h.log.Debug(fmt.Sprintf("user fetch succesfull. userId: %s", userId))
-	err = json.NewEncoder(rw).Encode(user)
+	rw.Header().Set("ETag", etag.Format(user.Version))
+	err = json.NewEncoder(rw).Encode(ToGetResponse(user))
 	if err != nil {
 		h.log.Debug(fmt.Sprintf("unable to marshall user. err: %s", err.Error()))
 	}
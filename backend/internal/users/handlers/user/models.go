@@ -1,5 +1,7 @@
 package user
 
+import "github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+
 type UpdateRequest struct {
 	ProfileURL string `json:"profile_url,omitempty"`
 	Name       string `json:"name,omitempty" validate:"required,min=2,max=20"`
@@ -10,3 +12,12 @@ type GetResponse struct {
 	ProfileURL string `json:"profile_url,omitempty"`
 	Name       string `json:"name,omitempty"`
 }
+
+// ToGetResponse leaves out the version, which is sent as the ETag header instead.
+func ToGetResponse(user *domain.User) *GetResponse {
+	return &GetResponse{
+		UserID:     user.UserID,
+		ProfileURL: user.ImageURL,
+		Name:       user.Name,
+	}
+}
@@ -0,0 +1,86 @@
+package verification
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	jsonHelper "github.com/hardiksachan/kanban_board/backend/shared/json"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+	"net/http"
+)
+
+type Handler struct {
+	verification *ports.VerificationService
+	log          logging.Logger
+	validate     *validator.Validate
+}
+
+func NewVerificationHandler(verification *ports.VerificationService, log logging.Logger, validator *validator.Validate) *Handler {
+	return &Handler{verification, log, validator}
+}
+
+func (h *Handler) Verify(rw http.ResponseWriter, r *http.Request) {
+	rm, ok := parseRequest[VerifyRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.verification.Verify(rm.Token)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug("email verified successfully")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// Resend always answers 202, whether or not the address belongs to an unverified account.
+func (h *Handler) Resend(rw http.ResponseWriter, r *http.Request) {
+	rm, ok := parseRequest[ResendRequest](h, rw, r)
+	if !ok {
+		return
+	}
+
+	err := h.verification.Resend(rm.Email)
+	if err != nil {
+		h.writeError(rw, err)
+		return
+	}
+
+	h.log.Debug("verification email resent")
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func parseRequest[T interface{}](h *Handler, rw http.ResponseWriter, r *http.Request) (*T, bool) {
+	rm, err := jsonHelper.Parse[T](r.Body)
+	if err != nil {
+		h.log.Debug(fmt.Sprintf("unable to parse request body. err: %s", err.Error()))
+
+		http.Error(rw, "unable to parse request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// validate and sanitize input
+	validationErr := h.validate.Struct(rm)
+	if validationErr != nil {
+		h.log.Debug(fmt.Sprintf("invalid request Body. err: %s", validationErr.Error()))
+
+		http.Error(rw, fmt.Sprintf("invalid request. %s", validationErr.Error()), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return rm, true
+}
+
+func (h *Handler) writeError(rw http.ResponseWriter, err error) {
+	switch users.ErrorCode(err) {
+	case users.EINVALID, users.EEXPIRED, users.ENOTFOUND:
+		h.log.Debug(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusBadRequest)
+	default:
+		h.log.Warn(err.Error())
+		http.Error(rw, users.ErrorMessage(err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,9 @@
+package verification
+
+type VerifyRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
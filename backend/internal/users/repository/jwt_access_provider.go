@@ -1,39 +1,57 @@
 package repository
 
 import (
+	"fmt"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"github.com/hardiksachan/kanban_board/backend/internal/users"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"strings"
 	"time"
 )
 
+// Claims is the payload of an access token. The user is the subject, and scopes are space
+// separated as in OAuth 2.0.
 type Claims struct {
-	domain.AccessClaims
+	SessionID string   `json:"sid,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
 	jwt.StandardClaims
 }
 
 type JWTAccessProvider struct {
-	jwtKey         []byte
+	keys           *KeySet
+	issuer         string
+	audience       string
 	expiryDuration time.Duration
+	// clockSkew is how far the clocks of the servers issuing and verifying tokens may be apart.
+	clockSkew time.Duration
 }
 
-func NewAccessTokenStore(jwtKey string, expiryDuration time.Duration) *JWTAccessProvider {
-	return &JWTAccessProvider{[]byte(jwtKey), expiryDuration}
+func NewAccessTokenStore(keys *KeySet, issuer, audience string, expiryDuration, clockSkew time.Duration) *JWTAccessProvider {
+	return &JWTAccessProvider{keys, issuer, audience, expiryDuration, clockSkew}
 }
 
-func (p *JWTAccessProvider) Create(credential *domain.AccessClaims) (*domain.AccessToken, error) {
+func (p *JWTAccessProvider) Create(accessClaims *domain.AccessClaims) (*domain.AccessToken, error) {
 	op := "JWTAccessProvider.Create"
 
+	now := time.Now()
 	claims := &Claims{
-		AccessClaims: domain.AccessClaims{
-			UserID: credential.UserID,
-		},
+		SessionID: accessClaims.SessionID,
+		Roles:     accessClaims.Roles,
+		Scope:     strings.Join(accessClaims.Scopes, " "),
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(p.expiryDuration).Unix(),
+			Id:        uuid.NewString(),
+			Subject:   accessClaims.UserID,
+			Issuer:    p.issuer,
+			Audience:  p.audience,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(p.expiryDuration).Unix(),
 		},
 	}
 
-	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(p.jwtKey)
+	signedToken, err := p.keys.sign(jwt.NewWithClaims(jwt.SigningMethodHS256, claims))
 	if err != nil {
 		return nil, &users.Error{Op: op, Err: err}
 	}
@@ -46,26 +64,61 @@ func (p *JWTAccessProvider) Verify(accessToken *domain.AccessToken) (*domain.Acc
 
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(string(*accessToken), claims, func(token *jwt.Token) (interface{}, error) {
-		return p.jwtKey, nil
-	})
-
+	// the time based claims are checked below, allowing for clock skew
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.ParseWithClaims(string(*accessToken), claims, p.keys.verificationKey)
 	if err != nil {
-		if validationError, ok := (err).(*jwt.ValidationError); ok {
-			if validationError.Errors&jwt.ValidationErrorExpired != 0 {
-				return nil, &users.Error{Op: op, Code: users.EEXPIRED, Message: "access token has expired"}
-			}
-		}
-		return nil, &users.Error{Op: op, Err: err}
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "access token is invalid", Err: err}
 	}
 
 	if !token.Valid {
 		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "access token is invalid"}
 	}
 
-	if claims.StandardClaims.ExpiresAt < time.Now().Unix() {
-		return nil, &users.Error{Op: op, Code: users.EEXPIRED, Message: "access token has expired"}
+	err = p.validate(claims)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
 	}
 
-	return &claims.AccessClaims, nil
+	var scopes []string
+	if claims.Scope != "" {
+		scopes = strings.Split(claims.Scope, " ")
+	}
+
+	return &domain.AccessClaims{
+		UserID:    claims.Subject,
+		SessionID: claims.SessionID,
+		TokenID:   claims.Id,
+		Roles:     claims.Roles,
+		Scopes:    scopes,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+func (p *JWTAccessProvider) PublicKeys() []*domain.PublicKey {
+	return p.keys.PublicKeys()
+}
+
+// validate checks that the token was issued by us, for us, and is valid now.
+func (p *JWTAccessProvider) validate(claims *Claims) error {
+	op := "JWTAccessProvider.validate"
+	now := time.Now()
+
+	if claims.Subject == "" || claims.Id == "" {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "access token is invalid"}
+	}
+	if !claims.VerifyIssuer(p.issuer, true) {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: fmt.Sprintf("access token was not issued by %s", p.issuer)}
+	}
+	if !claims.VerifyAudience(p.audience, true) {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: fmt.Sprintf("access token is not meant for %s", p.audience)}
+	}
+	if !claims.VerifyIssuedAt(now.Add(p.clockSkew).Unix(), true) || !claims.VerifyNotBefore(now.Add(p.clockSkew).Unix(), true) {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "access token is not valid yet"}
+	}
+	if !claims.VerifyExpiresAt(now.Add(-p.clockSkew).Unix(), true) {
+		return &users.Error{Op: op, Code: users.EEXPIRED, Message: "access token has expired"}
+	}
+	return nil
 }
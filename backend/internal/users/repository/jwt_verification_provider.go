@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"github.com/golang-jwt/jwt"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"time"
+)
+
+// verificationPurpose is mixed into the signing key, so that a verification token can never pass
+// as an access token even when both are configured with the same secret.
+const verificationPurpose = "verify-email:"
+
+type VerificationClaims struct {
+	UserID string `json:"uid"`
+	Email  string `json:"email"`
+	jwt.StandardClaims
+}
+
+type JWTVerificationProvider struct {
+	jwtKey         []byte
+	expiryDuration time.Duration
+}
+
+func NewVerificationProvider(secret string, expiryDuration time.Duration) *JWTVerificationProvider {
+	key := sha256.Sum256([]byte(verificationPurpose + secret))
+	return &JWTVerificationProvider{key[:], expiryDuration}
+}
+
+func (p *JWTVerificationProvider) Create(userID, email string) (string, error) {
+	op := "JWTVerificationProvider.Create"
+
+	claims := &VerificationClaims{
+		UserID: userID,
+		Email:  email,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(p.expiryDuration).Unix(),
+		},
+	}
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(p.jwtKey)
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	return signedToken, nil
+}
+
+func (p *JWTVerificationProvider) Verify(token string) (string, string, error) {
+	op := "JWTVerificationProvider.Verify"
+
+	claims := &VerificationClaims{}
+
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return p.jwtKey, nil
+	})
+	if err != nil {
+		if validationError, ok := (err).(*jwt.ValidationError); ok {
+			if validationError.Errors&jwt.ValidationErrorExpired != 0 {
+				return "", "", &users.Error{Op: op, Code: users.EEXPIRED, Message: "verification link has expired"}
+			}
+		}
+		return "", "", &users.Error{Op: op, Code: users.EINVALID, Message: "verification link is invalid", Err: err}
+	}
+
+	if !parsed.Valid || claims.UserID == "" || claims.Email == "" {
+		return "", "", &users.Error{Op: op, Code: users.EINVALID, Message: "verification link is invalid"}
+	}
+
+	return claims.UserID, claims.Email, nil
+}
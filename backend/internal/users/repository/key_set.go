@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// hmacKeyID identifies the shared secret of a KeySet built by NewHMACKeySet.
+const hmacKeyID = "hs256"
+
+type signingKey struct {
+	method jwt.SigningMethod
+	// private is nil for keys that only verify, like keys other instances sign with.
+	private interface{}
+	public  interface{}
+}
+
+// KeySet holds the keys access tokens are signed and verified with, identified by the kid
+// header of the token.
+//
+// Keys rotate without downtime in three steps: add the new key to every instance, then make it
+// the signing key, and finally remove the old key once the last token signed with it expired.
+type KeySet struct {
+	keys         map[string]*signingKey
+	signingKeyID string
+}
+
+// LoadKeySet reads every .pem file in dir, using the file name without extension as the key ID.
+// Files hold an RSA or Ed25519 private key, or just a public key to verify tokens signed
+// elsewhere. signingKeyID picks the key new tokens are signed with.
+func LoadKeySet(dir, signingKeyID string) (*KeySet, error) {
+	op := "KeySet.Load"
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	keys := map[string]*signingKey{}
+	for _, path := range paths {
+		keyID := strings.TrimSuffix(filepath.Base(path), ".pem")
+
+		key, err := readKey(path)
+		if err != nil {
+			return nil, &users.Error{Op: op, Message: fmt.Sprintf("unable to read key (%s)", keyID), Err: err}
+		}
+		keys[keyID] = key
+	}
+
+	signing, ok := keys[signingKeyID]
+	if !ok || signing.private == nil {
+		return nil, &users.Error{Op: op, Message: fmt.Sprintf("no private key with id (%s) in %s", signingKeyID, dir)}
+	}
+
+	return &KeySet{keys, signingKeyID}, nil
+}
+
+// NewHMACKeySet signs and verifies with a shared secret. Only this server can verify its
+// tokens, so it is meant for local development.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{
+		keys: map[string]*signingKey{
+			hmacKeyID: {method: jwt.SigningMethodHS256, private: []byte(secret), public: []byte(secret)},
+		},
+		signingKeyID: hmacKeyID,
+	}
+}
+
+// sign signs the token with the signing key, naming it in the kid header.
+func (s *KeySet) sign(token *jwt.Token) (string, error) {
+	key := s.keys[s.signingKeyID]
+
+	token.Method = key.method
+	token.Header["alg"] = key.method.Alg()
+	token.Header["kid"] = s.signingKeyID
+	return token.SignedString(key.private)
+}
+
+// verificationKey finds the key a token claims to be signed with. The algorithm has to be the
+// key's own, so a token cannot pick how its signature is checked.
+func (s *KeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	key, ok := s.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id (%s)", keyID)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("key (%s) does not sign with %s", keyID, token.Method.Alg())
+	}
+	return key.public, nil
+}
+
+// PublicKeys returns the keys that can be published for others to verify tokens with, sorted by
+// key ID. Shared secrets are left out.
+func (s *KeySet) PublicKeys() []*domain.PublicKey {
+	publicKeys := make([]*domain.PublicKey, 0, len(s.keys))
+	for keyID, key := range s.keys {
+		if _, ok := key.public.([]byte); ok {
+			continue
+		}
+		publicKeys = append(publicKeys, &domain.PublicKey{
+			KeyID:     keyID,
+			Algorithm: key.method.Alg(),
+			Key:       key.public,
+		})
+	}
+
+	sort.Slice(publicKeys, func(i, j int) bool {
+		return publicKeys[i].KeyID < publicKeys[j].KeyID
+	})
+	return publicKeys
+}
+
+func readKey(path string) (*signingKey, error) {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(encoded)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block (%s)", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &signingKey{method: jwt.SigningMethodRS256, private: key, public: &key.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &signingKey{method: jwt.SigningMethodRS256, public: key}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{method: jwt.SigningMethodEdDSA, private: key, public: key.Public().(ed25519.PublicKey)}, nil
+	case ed25519.PublicKey:
+		return &signingKey{method: jwt.SigningMethodEdDSA, public: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", key)
+	}
+}
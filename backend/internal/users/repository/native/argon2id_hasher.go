@@ -0,0 +1,122 @@
+package native
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+const (
+	// argon2idPrefix starts hashes in the PHC string format:
+	// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+	argon2idPrefix = "$argon2id$"
+	// argon2idSaltLength and argon2idKeyLength are in bytes.
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+)
+
+// argon2idParams are the costs of an Argon2id hash. Memory is in KiB.
+type argon2idParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func (p *argon2idParams) key(password string, salt []byte, length uint32) []byte {
+	return argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.parallelism, length)
+}
+
+func (p *argon2idParams) matches(password string, salt, key []byte) bool {
+	return subtle.ConstantTimeCompare(p.key(password, salt, uint32(len(key))), key) == 1
+}
+
+// Argon2idHasher hashes passwords with Argon2id, encoded in the PHC string format.
+type Argon2idHasher struct {
+	params *argon2idParams
+}
+
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8) *Argon2idHasher {
+	return &Argon2idHasher{&argon2idParams{memory, iterations, parallelism}}
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	op := "native.Argon2idHasher.Hash"
+
+	salt := make([]byte, argon2idSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	key := h.params.key(password, salt, argon2idKeyLength)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.memory,
+		h.params.iterations,
+		h.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(password, hash string) (bool, error) {
+	op := "native.Argon2idHasher.Verify"
+
+	valid, err := verifyPassword(password, hash)
+	if err != nil {
+		return false, &users.Error{Op: op, Err: err}
+	}
+	return valid, nil
+}
+
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2id(hash)
+	if err != nil {
+		// not an Argon2id hash
+		return true
+	}
+	return params.memory < h.params.memory ||
+		params.iterations < h.params.iterations ||
+		params.parallelism < h.params.parallelism
+}
+
+func decodeArgon2id(hash string) (*argon2idParams, []byte, []byte, error) {
+	// the hash starts with a $, so the first part is empty
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, fmt.Errorf("not an argon2id hash")
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2id version: %w", err)
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2id version %d", version)
+	}
+
+	var params argon2idParams
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2id key: %w", err)
+	}
+
+	return &params, salt, key, nil
+}
@@ -0,0 +1,97 @@
+package native
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArgon2idHasherHash(t *testing.T) {
+	hasher := NewArgon2idHasher(64, 1, 1)
+
+	hash, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("got hash %q, want the PHC format with the hasher's parameters", hash)
+	}
+
+	other, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if hash == other {
+		t.Errorf("got the same hash twice, want a new salt every time")
+	}
+}
+
+func TestArgon2idHasherVerify(t *testing.T) {
+	hasher := NewArgon2idHasher(64, 1, 1)
+	hash, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+		wantErr  bool
+	}{
+		{"right password", "correct horse", hash, true, false},
+		{"wrong password", "correct horse!", hash, false, false},
+		{"empty password", "", hash, false, false},
+		{"hash made with other costs", "correct horse", mustHash(t, NewArgon2idHasher(128, 2, 2), "correct horse"), true, false},
+		{"bcrypt hash", "correct horse", mustHash(t, NewBcryptHasher(4), "correct horse"), true, false},
+		{"malformed hash", "correct horse", "$argon2id$v=19$m=64,t=1,p=1$salt", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hasher.Verify(tt.password, tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2idHasherNeedsRehash(t *testing.T) {
+	hasher := NewArgon2idHasher(128, 2, 2)
+
+	tests := []struct {
+		name string
+		hash string
+		want bool
+	}{
+		{"same costs", mustHash(t, hasher, "pw"), false},
+		{"higher costs", mustHash(t, NewArgon2idHasher(256, 3, 4), "pw"), false},
+		{"less memory", mustHash(t, NewArgon2idHasher(64, 2, 2), "pw"), true},
+		{"fewer iterations", mustHash(t, NewArgon2idHasher(128, 1, 2), "pw"), true},
+		{"less parallelism", mustHash(t, NewArgon2idHasher(128, 2, 1), "pw"), true},
+		{"bcrypt hash", mustHash(t, NewBcryptHasher(4), "pw"), true},
+		{"garbage", "not a hash", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasher.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustHash(t *testing.T, hasher interface{ Hash(string) (string, error) }, password string) string {
+	t.Helper()
+
+	hash, err := hasher.Hash(password)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	return hash
+}
@@ -0,0 +1,44 @@
+package native
+
+import (
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher hashes passwords with bcrypt, in the usual "$2a$<cost>$<salt and hash>" format.
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	op := "native.BcryptHasher.Hash"
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Verify(password, hash string) (bool, error) {
+	op := "native.BcryptHasher.Verify"
+
+	valid, err := verifyPassword(password, hash)
+	if err != nil {
+		return false, &users.Error{Op: op, Err: err}
+	}
+	return valid, nil
+}
+
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		// not a bcrypt hash
+		return true
+	}
+	return cost < h.cost
+}
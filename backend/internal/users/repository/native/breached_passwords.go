@@ -0,0 +1,65 @@
+package native
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// hashPrefixLength is how many hex characters of a SHA-1 hash pick its range, as in the
+// k-anonymity API of Have I Been Pwned.
+const hashPrefixLength = 5
+
+// BreachedPasswords is a list of passwords known from data breaches. Like the Pwned Passwords
+// API, it only ever compares SHA-1 hashes, grouped in ranges by their first characters, so
+// swapping it for the API later only means fetching ranges instead of reading them from memory.
+type BreachedPasswords struct {
+	// ranges maps hash prefixes to the suffixes of the breached hashes that start with them.
+	ranges map[string]map[string]struct{}
+}
+
+// LoadBreachedPasswords reads a list in the format Pwned Passwords can be downloaded in: one
+// uppercase SHA-1 hash per line, optionally followed by a colon and how often it was seen.
+func LoadBreachedPasswords(path string) (*BreachedPasswords, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	b := &BreachedPasswords{ranges: map[string]map[string]struct{}{}}
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		hash, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if hash == "" {
+			continue
+		}
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("%s:%d: not a SHA-1 hash", path, line)
+		}
+
+		hash = strings.ToUpper(hash)
+		prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+		if b.ranges[prefix] == nil {
+			b.ranges[prefix] = map[string]struct{}{}
+		}
+		b.ranges[prefix][suffix] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (b *BreachedPasswords) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, found := b.ranges[hash[:hashPrefixLength]][hash[hashPrefixLength:]]
+	return found
+}
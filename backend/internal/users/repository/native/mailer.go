@@ -0,0 +1,21 @@
+package native
+
+import (
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+)
+
+// LogMailer writes mails to the log instead of sending them, for local development.
+type LogMailer struct {
+	log logging.Logger
+}
+
+func NewLogMailer(log logging.Logger) *LogMailer {
+	return &LogMailer{log}
+}
+
+func (m *LogMailer) Send(mail *domain.Mail) error {
+	m.log.Debug(fmt.Sprintf("mail to: %s, subject: %s\n%s", mail.To, mail.Subject, mail.Body))
+	return nil
+}
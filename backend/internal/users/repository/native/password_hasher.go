@@ -0,0 +1,31 @@
+package native
+
+import (
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// verifyPassword checks the password against a hash of any algorithm the hashers know, which lets
+// users keep logging in with hashes made before the hasher was changed.
+func verifyPassword(password, hash string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		return params.matches(password, salt, key), nil
+	case strings.HasPrefix(hash, "$2"):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown password hash format")
+	}
+}
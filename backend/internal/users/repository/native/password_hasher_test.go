@@ -0,0 +1,39 @@
+package native
+
+import "testing"
+
+func TestVerifyPassword(t *testing.T) {
+	argon2id := mustHash(t, NewArgon2idHasher(64, 1, 1), "correct horse")
+	bcrypt := mustHash(t, NewBcryptHasher(4), "correct horse")
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+		wantErr  bool
+	}{
+		{"argon2id, right password", "correct horse", argon2id, true, false},
+		{"argon2id, wrong password", "wrong horse", argon2id, false, false},
+		{"bcrypt, right password", "correct horse", bcrypt, true, false},
+		{"bcrypt, wrong password", "wrong horse", bcrypt, false, false},
+		{"argon2id, unsupported version", "correct horse", "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5", false, true},
+		{"argon2id, bad parameters", "correct horse", "$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHQ$a2V5", false, true},
+		{"argon2id, bad salt", "correct horse", "$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5", false, true},
+		{"bcrypt, truncated", "correct horse", bcrypt[:20], false, true},
+		{"unknown format", "correct horse", "plaintext", false, true},
+		{"empty hash", "correct horse", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyPassword(tt.password, tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
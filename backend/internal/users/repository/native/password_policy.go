@@ -0,0 +1,123 @@
+package native
+
+import (
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxPasswordLength keeps hashing cheap enough that long passwords cannot slow the server down.
+	maxPasswordLength = 128
+	// minPersonalLength is how long parts of personal information must be to count. Shorter
+	// ones, like initials, are too likely to be in a password by chance.
+	minPersonalLength = 3
+)
+
+type PasswordPolicy struct {
+	// minLength is the fewest characters a password may have.
+	minLength int
+	// minClasses is how many of lowercase letters, uppercase letters, digits and other
+	// characters a password must mix.
+	minClasses int
+	// breached may be nil, when no list of breached passwords is configured.
+	breached *BreachedPasswords
+}
+
+func NewPasswordPolicy(minLength, minClasses int, breached *BreachedPasswords) *PasswordPolicy {
+	return &PasswordPolicy{minLength, minClasses, breached}
+}
+
+func (p *PasswordPolicy) Check(password string, personal ...string) error {
+	op := "native.PasswordPolicy.Check"
+
+	var reasons []users.Reason
+
+	length := utf8.RuneCountInString(password)
+	if length < p.minLength {
+		reasons = append(reasons, users.Reason{
+			Code:    domain.PasswordTooShort,
+			Message: fmt.Sprintf("use at least %d characters", p.minLength),
+		})
+	}
+	if length > maxPasswordLength {
+		reasons = append(reasons, users.Reason{
+			Code:    domain.PasswordTooLong,
+			Message: fmt.Sprintf("use at most %d characters", maxPasswordLength),
+		})
+	}
+
+	if characterClasses(password) < p.minClasses {
+		reasons = append(reasons, users.Reason{
+			Code:    domain.PasswordTooFewClasses,
+			Message: fmt.Sprintf("mix at least %d of lowercase letters, uppercase letters, digits and symbols", p.minClasses),
+		})
+	}
+
+	if containsPersonal(password, personal) {
+		reasons = append(reasons, users.Reason{
+			Code:    domain.PasswordContainsPersonal,
+			Message: "do not use your name or email address",
+		})
+	}
+
+	if p.breached != nil && p.breached.Contains(password) {
+		reasons = append(reasons, users.Reason{
+			Code:    domain.PasswordBreached,
+			Message: "this password appeared in a data breach, choose another one",
+		})
+	}
+
+	if len(reasons) != 0 {
+		return &users.Error{Op: op, Code: users.EINVALID, Message: "password is not allowed", Reasons: reasons}
+	}
+	return nil
+}
+
+// characterClasses counts which of lowercase letters, uppercase letters, digits and other
+// characters the password has.
+func characterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+// containsPersonal reports whether the password contains any part of the personal information,
+// ignoring case. Email addresses and names are split into their parts, so that neither
+// "jane.doe" nor "doe" is allowed for jane.doe@example.com.
+func containsPersonal(password string, personal []string) bool {
+	password = strings.ToLower(password)
+
+	for _, info := range personal {
+		info = strings.ToLower(info)
+		if local, _, found := strings.Cut(info, "@"); found {
+			info = local
+		}
+
+		parts := strings.FieldsFunc(info, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		parts = append(parts, info)
+
+		for _, part := range parts {
+			if utf8.RuneCountInString(part) >= minPersonalLength && strings.Contains(password, part) {
+				return true
+			}
+		}
+	}
+	return false
+}
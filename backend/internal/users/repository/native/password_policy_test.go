@@ -0,0 +1,97 @@
+package native
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	breached := loadBreached(t, "Summer2023!")
+	policy := NewPasswordPolicy(10, 3, breached)
+
+	tests := []struct {
+		name     string
+		password string
+		personal []string
+		want     []string
+	}{
+		{"allowed", "correct-Horse-battery", nil, nil},
+		{"too short", "aB3!", nil, []string{domain.PasswordTooShort}},
+		{"length counts characters, not bytes", "Ünïcödé-pä", nil, nil},
+		{"too long", "aB3!" + strings.Repeat("x", maxPasswordLength), nil, []string{domain.PasswordTooLong}},
+		{"too few classes", "onlylowercaseletters", nil, []string{domain.PasswordTooFewClasses}},
+		{"contains name", "Ada-Lovelace-1815", []string{"ada@example.com", "Ada Lovelace"}, []string{domain.PasswordContainsPersonal}},
+		{"contains email local part", "x-ada.byron-99X", []string{"ada.byron@example.com"}, []string{domain.PasswordContainsPersonal}},
+		{"contains part of email local part", "Byron-rules-99", []string{"ada.byron@example.com"}, []string{domain.PasswordContainsPersonal}},
+		{"ignores short personal parts", "al-Correct-horse-1", []string{"Al Li"}, nil},
+		{"ignores email domain", "Example-Horse-42", []string{"ada@example.com"}, nil},
+		{"breached", "Summer2023!", nil, []string{domain.PasswordBreached}},
+		{"reports every reason", "ada", []string{"ada@example.com"}, []string{
+			domain.PasswordTooShort,
+			domain.PasswordTooFewClasses,
+			domain.PasswordContainsPersonal,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password, tt.personal...)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("got %v, want the password allowed", err)
+				}
+				return
+			}
+
+			if code := users.ErrorCode(err); code != users.EINVALID {
+				t.Fatalf("got code %q, want %q", code, users.EINVALID)
+			}
+			var got []string
+			for _, reason := range users.ErrorReasons(err) {
+				got = append(got, reason.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got reasons %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyCheckWithoutBreachedList(t *testing.T) {
+	policy := NewPasswordPolicy(10, 3, nil)
+
+	err := policy.Check("Summer2023!")
+	if err != nil {
+		t.Fatalf("got %v, want the password allowed", err)
+	}
+}
+
+// loadBreached writes the passwords to a list in the Pwned Passwords format and loads it.
+func loadBreached(t *testing.T, passwords ...string) *BreachedPasswords {
+	t.Helper()
+
+	var list strings.Builder
+	for _, password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		list.WriteString(strings.ToUpper(hex.EncodeToString(sum[:])) + ":42\n")
+	}
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(path, []byte(list.String()), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	breached, err := LoadBreachedPasswords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return breached
+}
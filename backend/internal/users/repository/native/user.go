@@ -18,7 +18,7 @@ func NewUserStore() *UserStore {
 func (s *UserStore) Insert(user *domain.Credential) (*domain.Credential, error) {
 	s.id++
 
-	user.ID = strconv.Itoa(s.id)
+	user.UserID = strconv.Itoa(s.id)
 	s.users = append(s.users, user)
 
 	return user, nil
@@ -31,7 +31,7 @@ func (s *UserStore) Update(user *domain.Credential) error {
 
 func (s *UserStore) Remove(user *domain.Credential) error {
 	for i, u := range s.users {
-		if u.ID == user.ID {
+		if u.UserID == user.UserID {
 			s.users = append(s.users[:i], s.users[i+1:]...)
 			return nil
 		}
@@ -46,7 +46,7 @@ func (s *UserStore) Remove(user *domain.Credential) error {
 
 func (s *UserStore) FindById(userId string) (*domain.Credential, error) {
 	for _, user := range s.users {
-		if userId == user.ID {
+		if userId == user.UserID {
 			return user, nil
 		}
 	}
@@ -15,7 +15,7 @@ func NewUserMetadataStore() *UserMetadataStore {
 
 func (s *UserMetadataStore) Update(user *domain.User) error {
 	for _, metadata := range s.users {
-		if metadata.UserId == user.UserId {
+		if metadata.UserID == user.UserID {
 			metadata.Name = user.Name
 			metadata.ImageURL = user.ImageURL
 			return nil
@@ -28,7 +28,7 @@ func (s *UserMetadataStore) Update(user *domain.User) error {
 
 func (s *UserMetadataStore) Get(userID string) (*domain.User, error) {
 	for _, metadata := range s.users {
-		if metadata.UserId == userID {
+		if metadata.UserID == userID {
 			return metadata, nil
 		}
 	}
@@ -0,0 +1,26 @@
+package native
+
+import (
+	"fmt"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/shared/logging"
+)
+
+// VerificationSender sends verification links through the verification service, logging links it
+// cannot send.
+type VerificationSender struct {
+	verification *ports.VerificationService
+	log          logging.Logger
+}
+
+func NewVerificationSender(verification *ports.VerificationService, log logging.Logger) *VerificationSender {
+	return &VerificationSender{verification, log}
+}
+
+func (s *VerificationSender) Send(credential *domain.Credential) {
+	err := s.verification.Send(credential)
+	if err != nil {
+		s.log.Warn(fmt.Sprintf("unable to send verification link. userId: %s, err: %s", credential.UserID, err.Error()))
+	}
+}
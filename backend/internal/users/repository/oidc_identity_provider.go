@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"golang.org/x/oauth2"
+)
+
+// OIDCIdentityProvider logs users in with an OpenID Connect provider, such as a company's single
+// sign-on.
+type OIDCIdentityProvider struct {
+	name     string
+	config   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCIdentityProvider discovers the provider's endpoints and keys from issuer. redirectURL
+// is where the provider sends users back to, and must be registered with it.
+func NewOIDCIdentityProvider(name, issuer, clientID, clientSecret, redirectURL string) (*OIDCIdentityProvider, error) {
+	op := "OIDCIdentityProvider.New"
+
+	provider, err := oidc.NewProvider(context.Background(), issuer)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	return &OIDCIdentityProvider{
+		name: name,
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  redirectURL,
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: clientID}),
+	}, nil
+}
+
+func (p *OIDCIdentityProvider) AuthCodeURL(state, nonce, verifier string) string {
+	challenge := sha256.Sum256([]byte(verifier))
+
+	return p.config.AuthCodeURL(
+		state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+func (p *OIDCIdentityProvider) Exchange(code, verifier, nonce string) (*domain.ExternalIdentity, error) {
+	op := "OIDCIdentityProvider.Exchange"
+	msg := "login with the identity provider failed, try again"
+
+	ctx := context.Background()
+	token, err := p.config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
+	if err != nil {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: msg}
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+	}
+	if idToken.Nonce != nonce {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: msg}
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+	}
+	if claims.Email == "" {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "identity provider did not share an email address"}
+	}
+
+	return &domain.ExternalIdentity{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
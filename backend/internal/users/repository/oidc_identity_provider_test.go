@@ -0,0 +1,446 @@
+package repository_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	activityDomain "github.com/hardiksachan/kanban_board/backend/internal/activity/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/ports"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/repository"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	providerName = "corp"
+	clientID     = "kanban-board"
+)
+
+func TestCompleteRejectsForeignState(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	env := newExternalAuthEnv(t, issuer)
+
+	linkURL, err := env.service.BeginLink(providerName, "user-1")
+	if err != nil {
+		t.Fatalf("BeginLink: %v", err)
+	}
+	loginURL, err := env.service.Begin(providerName)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		authURL  string
+		stateKey string
+	}{
+		{"unknown state", loginURL, "forged-state"},
+		{"state of a link", linkURL, stateOf(t, linkURL)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := issuer.authorize(t, tt.authURL, identityClaims{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true})
+
+			_, _, _, _, err := env.service.Complete(providerName, tt.stateKey, code, &domain.Client{})
+			if users.ErrorCode(err) != users.EINVALID {
+				t.Fatalf("Complete: got %v, want %s", err, users.EINVALID)
+			}
+			if len(env.credentials.byID) != 0 {
+				t.Fatalf("Complete created an account for a foreign state")
+			}
+		})
+	}
+}
+
+func TestCompleteRejectsNonceMismatch(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	env := newExternalAuthEnv(t, issuer)
+
+	authURL, err := env.service.Begin(providerName)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	code := issuer.authorize(t, authURL, identityClaims{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true, Nonce: "replayed-nonce"})
+
+	_, _, _, _, err = env.service.Complete(providerName, stateOf(t, authURL), code, &domain.Client{})
+	if users.ErrorCode(err) != users.EINVALID {
+		t.Fatalf("Complete: got %v, want %s", err, users.EINVALID)
+	}
+	if len(env.credentials.byID) != 0 {
+		t.Fatalf("Complete created an account for an ID token with a foreign nonce")
+	}
+}
+
+func TestCompleteLinksAccountWithSameEmail(t *testing.T) {
+	verifiedAt := time.Now()
+	tests := []struct {
+		name            string
+		accountVerified *time.Time
+		idpVerified     bool
+		wantCode        string
+	}{
+		{"both verified", &verifiedAt, true, ""},
+		{"account unverified", nil, true, users.ECONFLICT},
+		{"provider unverified", &verifiedAt, false, users.ECONFLICT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newFakeIssuer(t)
+			env := newExternalAuthEnv(t, issuer)
+			account := env.credentials.add(&domain.Credential{Email: "ada@example.com", EmailVerifiedAt: tt.accountVerified})
+
+			authURL, err := env.service.Begin(providerName)
+			if err != nil {
+				t.Fatalf("Begin: %v", err)
+			}
+			code := issuer.authorize(t, authURL, identityClaims{Subject: "sub-1", Email: "Ada@Example.com", EmailVerified: tt.idpVerified})
+
+			credential, _, _, _, err := env.service.Complete(providerName, stateOf(t, authURL), code, &domain.Client{})
+			if tt.wantCode != "" {
+				if users.ErrorCode(err) != tt.wantCode {
+					t.Fatalf("Complete: got %v, want %s", err, tt.wantCode)
+				}
+				if _, err := env.identities.Find(providerName, "sub-1"); users.ErrorCode(err) != users.ENOTFOUND {
+					t.Fatalf("identity was linked without both sides verifying the email")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Complete: %v", err)
+			}
+			if credential.UserID != account.UserID {
+				t.Fatalf("logged in as %s, want the existing account %s", credential.UserID, account.UserID)
+			}
+			identity, err := env.identities.Find(providerName, "sub-1")
+			if err != nil {
+				t.Fatalf("identity was not linked: %v", err)
+			}
+			if identity.UserID != account.UserID {
+				t.Fatalf("identity linked to %s, want %s", identity.UserID, account.UserID)
+			}
+		})
+	}
+}
+
+// identityClaims is what the fake issuer puts into the ID token of an authorization code. An
+// empty Nonce echoes the nonce of the authorization request.
+type identityClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Nonce         string
+}
+
+type grant struct {
+	challenge string
+	claims    identityClaims
+}
+
+// fakeIssuer is an OpenID Connect provider that hands out an authorization code for whatever
+// identity a test asks for, and redeems it like a real provider would: once, with the PKCE
+// verifier, for an ID token signed with its published key.
+type fakeIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]*grant
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	issuer := &fakeIssuer{key: key, grants: map[string]*grant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/keys", issuer.keys)
+	mux.HandleFunc("/token", issuer.token)
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+// authorize does what the provider does once the user logs in at authURL, returning the code
+// the user is sent back with.
+func (i *fakeIssuer) authorize(t *testing.T, authURL string, claims identityClaims) string {
+	query := queryOf(t, authURL)
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("authorization request without an S256 PKCE challenge: %s", authURL)
+	}
+	if claims.Nonce == "" {
+		claims.Nonce = query.Get("nonce")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	code := fmt.Sprintf("code-%d", len(i.grants)+1)
+	i.grants[code] = &grant{challenge: query.Get("code_challenge"), claims: claims}
+	return code
+}
+
+func (i *fakeIssuer) discovery(rw http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(rw).Encode(map[string]interface{}{
+		"issuer":                                i.URL,
+		"authorization_endpoint":                i.URL + "/auth",
+		"token_endpoint":                        i.URL + "/token",
+		"jwks_uri":                              i.URL + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (i *fakeIssuer) keys(rw http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(rw).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (i *fakeIssuer) token(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	i.mu.Lock()
+	g, ok := i.grants[r.PostForm.Get("code")]
+	delete(i.grants, r.PostForm.Get("code"))
+	i.mu.Unlock()
+
+	verifierHash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(verifierHash[:]) != g.challenge {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            i.URL,
+		"sub":            g.claims.Subject,
+		"aud":            clientID,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          g.claims.Nonce,
+		"email":          g.claims.Email,
+		"email_verified": g.claims.EmailVerified,
+	})
+	idToken.Header["kid"] = "test"
+	signed, err := idToken.SignedString(i.key)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(map[string]interface{}{
+		"access_token": "provider-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     signed,
+	})
+}
+
+// externalAuthEnv is an ExternalAuthService logging in through a fake issuer, with everything
+// else kept in memory.
+type externalAuthEnv struct {
+	service     *ports.ExternalAuthService
+	credentials *credentialStore
+	identities  *identityStore
+}
+
+func newExternalAuthEnv(t *testing.T, issuer *fakeIssuer) *externalAuthEnv {
+	provider, err := repository.NewOIDCIdentityProvider(providerName, issuer.URL, clientID, "secret", "http://localhost/oauth/callback")
+	if err != nil {
+		t.Fatalf("NewOIDCIdentityProvider: %v", err)
+	}
+
+	credentials := &credentialStore{byID: map[string]*domain.Credential{}}
+	identities := &identityStore{byKey: map[string]*domain.ExternalIdentity{}}
+	activity := recorder{}
+	auth := ports.NewAuthService(
+		credentials,
+		accessProvider{},
+		nil,
+		refreshStore{},
+		activity,
+		nil,
+		ports.NewMFAService(credentials, totpStore{}, activity, "test"),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+	)
+
+	return &externalAuthEnv{
+		service: ports.NewExternalAuthService(
+			auth,
+			map[string]ports.IdentityProvider{providerName: provider},
+			&stateStore{states: map[string]*domain.OAuthState{}},
+			identities,
+			credentials,
+			activity,
+		),
+		credentials: credentials,
+		identities:  identities,
+	}
+}
+
+func queryOf(t *testing.T, rawURL string) url.Values {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parse %s: %v", rawURL, err)
+	}
+	return parsed.Query()
+}
+
+func stateOf(t *testing.T, authURL string) string {
+	return queryOf(t, authURL).Get("state")
+}
+
+type stateStore struct {
+	mu     sync.Mutex
+	states map[string]*domain.OAuthState
+}
+
+func (s *stateStore) Create(state *domain.OAuthState) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("state-%d", len(s.states)+1)
+	s.states[key] = state
+	return key, nil
+}
+
+func (s *stateStore) Consume(key string) (*domain.OAuthState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		return nil, &users.Error{Code: users.EINVALID, Message: "login expired, try again"}
+	}
+	delete(s.states, key)
+	return state, nil
+}
+
+type identityStore struct {
+	byKey map[string]*domain.ExternalIdentity
+}
+
+func (s *identityStore) Find(provider, subject string) (*domain.ExternalIdentity, error) {
+	identity, ok := s.byKey[provider+"|"+subject]
+	if !ok {
+		return nil, &users.Error{Code: users.ENOTFOUND}
+	}
+	return identity, nil
+}
+
+func (s *identityStore) Insert(identity *domain.ExternalIdentity) (*domain.ExternalIdentity, error) {
+	key := identity.Provider + "|" + identity.Subject
+	if _, ok := s.byKey[key]; ok {
+		return nil, &users.Error{Code: users.ECONFLICT}
+	}
+	s.byKey[key] = identity
+	return identity, nil
+}
+
+// credentialStore keeps credentials in memory. Methods the login does not use are left to the
+// embedded nil interface, and panic.
+type credentialStore struct {
+	ports.CredentialStore
+	byID map[string]*domain.Credential
+}
+
+func (s *credentialStore) add(credential *domain.Credential) *domain.Credential {
+	credential.UserID = fmt.Sprintf("user-%d", len(s.byID)+1)
+	credential.Email = domain.NormalizeEmail(credential.Email)
+	s.byID[credential.UserID] = credential
+	return credential
+}
+
+func (s *credentialStore) Insert(credential *domain.Credential) (*domain.Credential, error) {
+	return s.add(credential), nil
+}
+
+func (s *credentialStore) MarkEmailVerified(userID, email string) (*domain.Credential, error) {
+	credential := s.byID[userID]
+	now := time.Now()
+	credential.EmailVerifiedAt = &now
+	return credential, nil
+}
+
+func (s *credentialStore) FindById(userID string) (*domain.Credential, error) {
+	credential, ok := s.byID[userID]
+	if !ok {
+		return nil, &users.Error{Code: users.ENOTFOUND}
+	}
+	return credential, nil
+}
+
+func (s *credentialStore) FindByEmail(email string) (*domain.Credential, error) {
+	for _, credential := range s.byID {
+		if strings.EqualFold(credential.Email, email) {
+			return credential, nil
+		}
+	}
+	return nil, &users.Error{Code: users.ENOTFOUND}
+}
+
+type totpStore struct {
+	ports.TOTPStore
+}
+
+func (totpStore) Find(userID string) (*domain.TOTP, error) {
+	return nil, &users.Error{Code: users.ENOTFOUND}
+}
+
+type refreshStore struct {
+	ports.RefreshStore
+}
+
+func (refreshStore) Create(userID string, client *domain.Client) (*domain.Session, *domain.RefreshToken, error) {
+	token := domain.RefreshToken("refresh-" + userID)
+	return &domain.Session{SessionID: "session-" + userID, UserID: userID, Client: *client}, &token, nil
+}
+
+type accessProvider struct {
+	ports.AccessProvider
+}
+
+func (accessProvider) Create(claims *domain.AccessClaims) (*domain.AccessToken, error) {
+	token := domain.AccessToken("access-" + claims.UserID)
+	return &token, nil
+}
+
+type recorder struct{}
+
+func (recorder) Record(*activityDomain.Activity) {}
@@ -2,20 +2,24 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"github.com/hardiksachan/kanban_board/backend/internal/users"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/postgres/user/dao"
 	"github.com/hardiksachan/kanban_board/backend/shared"
 	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
 	"strings"
+	"time"
 )
 
 type CredentialStore struct {
-	q *dao.Queries
+	db *pgxpool.Pool
+	q  *dao.Queries
 }
 
-func NewCredentialStore(q *dao.Queries) *CredentialStore {
-	return &CredentialStore{q}
+func NewCredentialStore(db *pgxpool.Pool) *CredentialStore {
+	return &CredentialStore{db, dao.New(db)}
 }
 
 func (s *CredentialStore) Insert(credential *domain.Credential) (*domain.Credential, error) {
@@ -27,15 +31,14 @@ func (s *CredentialStore) Insert(credential *domain.Credential) (*domain.Credent
 		Password: credential.Password,
 		Name:     strings.Split(credential.Email, "@")[0],
 	})
+	if isUniqueViolation(err) {
+		return nil, &users.Error{Code: users.ECONFLICT, Message: "an account with this email exists", Op: op, Err: err}
+	}
 	if err != nil {
 		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
 	}
 
-	return &domain.Credential{
-		UserID:   credentialRow.UserID.String(),
-		Email:    credentialRow.Email,
-		Password: credentialRow.Password,
-	}, nil
+	return toCredential(dao.FindByIdRow(credentialRow)), nil
 }
 
 func (s *CredentialStore) Update(credential *domain.Credential) error {
@@ -58,21 +61,198 @@ func (s *CredentialStore) Update(credential *domain.Credential) error {
 	return nil
 }
 
-func (s *CredentialStore) Remove(credential *domain.Credential) error {
-	op := "postgres.CredentialStore.Remove"
+// MarkEmailVerified records that the user proved to own email. It fails with users.ENOTFOUND when
+// email is no longer the user's address.
+func (s *CredentialStore) MarkEmailVerified(userID, email string) (*domain.Credential, error) {
+	op := "postgres.CredentialStore.MarkEmailVerified"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
 
 	ctx := context.Background()
+	dbUser, err := s.q.MarkEmailVerified(ctx, dao.MarkEmailVerifiedParams{
+		UserID: *userUuid,
+		Email:  email,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "verification link is no longer valid", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
 
-	userUuid, err := shared.GetUUIDFromString(credential.UserID)
+	return toCredential(dao.FindByIdRow(dbUser)), nil
+}
+
+func (s *CredentialStore) UpdateEmail(userID, oldEmail, newEmail string) (*domain.Credential, error) {
+	op := "postgres.CredentialStore.UpdateEmail"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
 	if err != nil {
-		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
 	}
 
-	_, err = s.q.DeleteUser(ctx, *userUuid)
+	ctx := context.Background()
+	dbUser, err := s.q.UpdateEmail(ctx, dao.UpdateEmailParams{
+		NewEmail: newEmail,
+		UserID:   *userUuid,
+		OldEmail: oldEmail,
+	})
+	if isUniqueViolation(err) {
+		return nil, &users.Error{Code: users.ECONFLICT, Message: "an account with this email exists", Op: op, Err: err}
+	}
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "confirmation link is no longer valid", Op: op, Err: err}
+	}
 	if err != nil {
-		return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
 	}
-	return nil
+
+	return toCredential(dao.FindByIdRow(dbUser)), nil
+}
+
+// MarkDeleted schedules the account for purging. It fails with users.ENOTFOUND when the account
+// does not exist or is already scheduled.
+func (s *CredentialStore) MarkDeleted(userID string) (*domain.Credential, error) {
+	op := "postgres.CredentialStore.MarkDeleted"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbUser, err := s.q.MarkDeleted(ctx, *userUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "user does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toCredential(dao.FindByIdRow(dbUser)), nil
+}
+
+// Restore takes the account off the purge schedule. It fails with users.ENOTFOUND when the
+// account is not scheduled.
+func (s *CredentialStore) Restore(userID string) (*domain.Credential, error) {
+	op := "postgres.CredentialStore.Restore"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbUser, err := s.q.RestoreDeleted(ctx, *userUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "account is not scheduled for deletion", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toCredential(dao.FindByIdRow(dbUser)), nil
+}
+
+// Lock keeps the user from logging in until Unlock is called. Locking a locked account keeps the
+// time it was first locked.
+func (s *CredentialStore) Lock(userID string) (*domain.Credential, error) {
+	op := "postgres.CredentialStore.Lock"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbUser, err := s.q.LockUser(ctx, *userUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "user does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toCredential(dao.FindByIdRow(dbUser)), nil
+}
+
+func (s *CredentialStore) Unlock(userID string) (*domain.Credential, error) {
+	op := "postgres.CredentialStore.Unlock"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbUser, err := s.q.UnlockUser(ctx, *userUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "user does not exist", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toCredential(dao.FindByIdRow(dbUser)), nil
+}
+
+func (s *CredentialStore) ListDeletedBefore(before time.Time) ([]string, error) {
+	op := "postgres.CredentialStore.ListDeletedBefore"
+
+	ctx := context.Background()
+	userUuids, err := s.q.ListDeletedBefore(ctx, before)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	userIDs := make([]string, 0, len(userUuids))
+	for _, userUuid := range userUuids {
+		userIDs = append(userIDs, userUuid.String())
+	}
+	return userIDs, nil
+}
+
+// Remove deletes the account for good. Boards shared with others are handed to a remaining
+// member and the user is taken off the cards assigned to them; boards nobody else is on go with
+// the account. Activity keeps the user's ID, which no longer leads anywhere.
+func (s *CredentialStore) Remove(credential *domain.Credential) error {
+	op := "postgres.CredentialStore.Remove"
+
+	userUuid, err := shared.GetUUIDFromString(credential.UserID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	return inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		err := q.PromoteHeirs(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+
+		err = q.HandOverBoards(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+
+		err = q.UnassignUser(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+
+		_, err = q.DeleteUser(ctx, *userUuid)
+		if err == pgx.ErrNoRows {
+			return &users.Error{Code: users.ENOTFOUND, Message: "user does not exist", Op: op, Err: err}
+		}
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
 }
 
 func (s *CredentialStore) FindById(userId string) (*domain.Credential, error) {
@@ -91,11 +271,7 @@ func (s *CredentialStore) FindById(userId string) (*domain.Credential, error) {
 	if err != nil {
 		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
 	}
-	return &domain.Credential{
-		UserID:   dbUser.UserID.String(),
-		Email:    dbUser.Email,
-		Password: dbUser.Password,
-	}, nil
+	return toCredential(dbUser), nil
 }
 
 func (s *CredentialStore) FindByEmail(email string) (*domain.Credential, error) {
@@ -110,11 +286,7 @@ func (s *CredentialStore) FindByEmail(email string) (*domain.Credential, error)
 		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
 	}
 
-	return &domain.Credential{
-		UserID:   dbUser.UserID.String(),
-		Email:    dbUser.Email,
-		Password: dbUser.Password,
-	}, nil
+	return toCredential(dao.FindByIdRow(dbUser)), nil
 }
 
 func (s *CredentialStore) CountByEmail(email string) (int, error) {
@@ -131,3 +303,24 @@ func (s *CredentialStore) CountByEmail(email string) (int, error) {
 
 	return int(count), nil
 }
+
+// toCredential maps a credential row. The credential queries all return the same columns, so
+// their rows convert to dao.FindByIdRow.
+func toCredential(row dao.FindByIdRow) *domain.Credential {
+	return &domain.Credential{
+		UserID:          row.UserID.String(),
+		Email:           row.Email,
+		Password:        row.Password,
+		EmailVerifiedAt: toTimePtr(row.EmailVerifiedAt),
+		DeletedAt:       toTimePtr(row.DeletedAt),
+		Roles:           row.Roles,
+		LockedAt:        toTimePtr(row.LockedAt),
+	}
+}
+
+func toTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/jackc/pgconn"
+)
+
+// uniqueViolation is the Postgres error code for a violated unique constraint.
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is Postgres rejecting a row that breaks a unique
+// constraint.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
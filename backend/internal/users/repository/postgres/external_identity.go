@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/postgres/user/dao"
+	"github.com/hardiksachan/kanban_board/backend/shared"
+	"github.com/jackc/pgx/v4"
+)
+
+type ExternalIdentityStore struct {
+	q *dao.Queries
+}
+
+func NewExternalIdentityStore(q *dao.Queries) *ExternalIdentityStore {
+	return &ExternalIdentityStore{q}
+}
+
+func (s *ExternalIdentityStore) Find(provider, subject string) (*domain.ExternalIdentity, error) {
+	op := "postgres.ExternalIdentityStore.Find"
+
+	ctx := context.Background()
+	dbIdentity, err := s.q.FindExternalIdentity(ctx, dao.FindExternalIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return &domain.ExternalIdentity{
+		Provider: dbIdentity.Provider,
+		Subject:  dbIdentity.Subject,
+		UserID:   dbIdentity.UserID.String(),
+		Email:    dbIdentity.Email,
+	}, nil
+}
+
+func (s *ExternalIdentityStore) Insert(identity *domain.ExternalIdentity) (*domain.ExternalIdentity, error) {
+	op := "postgres.ExternalIdentityStore.Insert"
+
+	userUuid, err := shared.GetUUIDFromString(identity.UserID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbIdentity, err := s.q.InsertExternalIdentity(ctx, dao.InsertExternalIdentityParams{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserID:   *userUuid,
+		Email:    identity.Email,
+	})
+	if isUniqueViolation(err) {
+		return nil, &users.Error{Code: users.ECONFLICT, Message: "account is already linked to this provider", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return &domain.ExternalIdentity{
+		Provider: dbIdentity.Provider,
+		Subject:  dbIdentity.Subject,
+		UserID:   dbIdentity.UserID.String(),
+		Email:    dbIdentity.Email,
+	}, nil
+}
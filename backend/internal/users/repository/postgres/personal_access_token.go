@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/postgres/user/dao"
+	"github.com/hardiksachan/kanban_board/backend/shared"
+	"github.com/jackc/pgx/v4"
+)
+
+type PersonalAccessTokenStore struct {
+	q *dao.Queries
+}
+
+func NewPersonalAccessTokenStore(q *dao.Queries) *PersonalAccessTokenStore {
+	return &PersonalAccessTokenStore{q}
+}
+
+func (s *PersonalAccessTokenStore) Insert(token *domain.PersonalAccessToken, tokenHash string) (*domain.PersonalAccessToken, error) {
+	op := "postgres.PersonalAccessTokenStore.Insert"
+
+	userUuid, err := shared.GetUUIDFromString(token.UserID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	expiresAt := sql.NullTime{}
+	if token.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *token.ExpiresAt, Valid: true}
+	}
+
+	ctx := context.Background()
+	dbToken, err := s.q.InsertPersonalAccessToken(ctx, dao.InsertPersonalAccessTokenParams{
+		ExpiresAt: expiresAt,
+		UserID:    *userUuid,
+		Name:      token.Name,
+		TokenHash: tokenHash,
+		Scopes:    token.Scopes,
+	})
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toPersonalAccessToken(dao.ListPersonalAccessTokensRow(dbToken)), nil
+}
+
+func (s *PersonalAccessTokenStore) List(userID string) ([]*domain.PersonalAccessToken, error) {
+	op := "postgres.PersonalAccessTokenStore.List"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbTokens, err := s.q.ListPersonalAccessTokens(ctx, *userUuid)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	tokens := make([]*domain.PersonalAccessToken, 0, len(dbTokens))
+	for _, dbToken := range dbTokens {
+		tokens = append(tokens, toPersonalAccessToken(dbToken))
+	}
+	return tokens, nil
+}
+
+func (s *PersonalAccessTokenStore) Delete(userID, tokenID string) error {
+	op := "postgres.PersonalAccessTokenStore.Delete"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+	tokenUuid, err := shared.GetUUIDFromString(tokenID)
+	if err != nil {
+		return &users.Error{Code: users.ENOTFOUND, Message: "token does not exist", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	deleted, err := s.q.DeletePersonalAccessToken(ctx, dao.DeletePersonalAccessTokenParams{
+		TokenID: *tokenUuid,
+		UserID:  *userUuid,
+	})
+	if err != nil {
+		return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+	if deleted == 0 {
+		return &users.Error{Code: users.ENOTFOUND, Message: "token does not exist", Op: op}
+	}
+	return nil
+}
+
+func (s *PersonalAccessTokenStore) DeleteAll(userID string) error {
+	op := "postgres.PersonalAccessTokenStore.DeleteAll"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	err = s.q.DeleteAllPersonalAccessTokens(ctx, *userUuid)
+	if err != nil {
+		return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+	return nil
+}
+
+func (s *PersonalAccessTokenStore) Use(tokenHash string) (*domain.PersonalAccessToken, error) {
+	op := "postgres.PersonalAccessTokenStore.Use"
+
+	ctx := context.Background()
+	dbToken, err := s.q.UsePersonalAccessToken(ctx, tokenHash)
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return toPersonalAccessToken(dao.ListPersonalAccessTokensRow(dbToken)), nil
+}
+
+func toPersonalAccessToken(dbToken dao.ListPersonalAccessTokensRow) *domain.PersonalAccessToken {
+	return &domain.PersonalAccessToken{
+		TokenID:    dbToken.TokenID.String(),
+		UserID:     dbToken.UserID.String(),
+		Name:       dbToken.Name,
+		Scopes:     dbToken.Scopes,
+		ExpiresAt:  toTimePtr(dbToken.ExpiresAt),
+		CreatedAt:  dbToken.CreatedAt,
+		LastUsedAt: toTimePtr(dbToken.LastUsedAt),
+	}
+}
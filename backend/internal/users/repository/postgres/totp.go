@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/repository/postgres/user/dao"
+	"github.com/hardiksachan/kanban_board/backend/shared"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TOTPStore struct {
+	db *pgxpool.Pool
+	q  *dao.Queries
+}
+
+func NewTOTPStore(db *pgxpool.Pool) *TOTPStore {
+	return &TOTPStore{db, dao.New(db)}
+}
+
+func (s *TOTPStore) Save(userID, secret string) error {
+	op := "postgres.TOTPStore.Save"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	saved, err := s.q.UpsertTOTP(ctx, dao.UpsertTOTPParams{
+		UserID: *userUuid,
+		Secret: secret,
+	})
+	if err != nil {
+		return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+	if saved == 0 {
+		return &users.Error{Code: users.ECONFLICT, Message: "two-factor authentication is already enabled", Op: op}
+	}
+	return nil
+}
+
+func (s *TOTPStore) Find(userID string) (*domain.TOTP, error) {
+	op := "postgres.TOTPStore.Find"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return nil, &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	dbTOTP, err := s.q.FindTOTP(ctx, *userUuid)
+	if err == pgx.ErrNoRows {
+		return nil, &users.Error{Code: users.ENOTFOUND, Message: "two-factor authentication is not set up", Op: op, Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+
+	return &domain.TOTP{
+		UserID:      dbTOTP.UserID.String(),
+		Secret:      dbTOTP.Secret,
+		ConfirmedAt: toTimePtr(dbTOTP.ConfirmedAt),
+	}, nil
+}
+
+func (s *TOTPStore) Confirm(userID string, recoveryCodeHashes []string) error {
+	op := "postgres.TOTPStore.Confirm"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	return inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		confirmed, err := q.ConfirmTOTP(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+		if confirmed == 0 {
+			return &users.Error{Code: users.ENOTFOUND, Message: "no two-factor setup to confirm", Op: op}
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+
+		err = q.InsertRecoveryCodes(ctx, dao.InsertRecoveryCodesParams{
+			UserID:     *userUuid,
+			CodeHashes: recoveryCodeHashes,
+		})
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+}
+
+func (s *TOTPStore) Remove(userID string) error {
+	op := "postgres.TOTPStore.Remove"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	return inTx(op, s.db, func(q *dao.Queries) error {
+		ctx := context.Background()
+
+		err := q.DeleteTOTP(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, *userUuid)
+		if err != nil {
+			return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+		return nil
+	})
+}
+
+func (s *TOTPStore) UseRecoveryCode(userID, recoveryCodeHash string) error {
+	op := "postgres.TOTPStore.UseRecoveryCode"
+
+	userUuid, err := shared.GetUUIDFromString(userID)
+	if err != nil {
+		return &users.Error{Code: users.EINVALID, Message: "Unable to parse UUID", Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	used, err := s.q.DeleteRecoveryCode(ctx, dao.DeleteRecoveryCodeParams{
+		UserID:   *userUuid,
+		CodeHash: recoveryCodeHash,
+	})
+	if err != nil {
+		return &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+	}
+	if used == 0 {
+		return &users.Error{Code: users.ENOTFOUND, Message: "recovery code is invalid", Op: op}
+	}
+	return nil
+}
@@ -9,8 +9,85 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 )
 
+type Activity struct {
+	ActivityID int64
+	ActorID    uuid.UUID
+	BoardID    uuid.NullUUID
+	Type       string
+	SubjectID  uuid.UUID
+	Details    pgtype.JSONB
+	CreatedAt  time.Time
+}
+
+type Board struct {
+	BoardID     uuid.UUID
+	OwnerID     uuid.UUID
+	Title       string
+	Description string
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+	Version     int32
+}
+
+type BoardColumn struct {
+	ColumnID   uuid.UUID
+	BoardID    uuid.UUID
+	Title      string
+	Rank       string
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+	Version    int32
+}
+
+type BoardMember struct {
+	BoardID   uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+	CreatedAt time.Time
+}
+
+type Card struct {
+	CardID      uuid.UUID
+	BoardID     uuid.UUID
+	ColumnID    uuid.UUID
+	Title       string
+	Description string
+	AssigneeIds []uuid.UUID
+	DueDate     sql.NullTime
+	Rank        string
+	Version     int32
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+type ExternalIdentity struct {
+	Provider  string
+	Subject   string
+	UserID    uuid.UUID
+	Email     string
+	CreatedAt time.Time
+}
+
+type PersonalAccessToken struct {
+	TokenID    uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	TokenHash  string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+}
+
+type RecoveryCode struct {
+	UserID    uuid.UUID
+	CodeHash  string
+	CreatedAt time.Time
+}
+
 type User struct {
 	UserID          uuid.UUID
 	Name            string
@@ -19,4 +96,16 @@ type User struct {
 	CreatedAt       time.Time
 	ModifiedAt      time.Time
 	ProfileImageUrl sql.NullString
+	Version         int32
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+type UserTotp struct {
+	UserID      uuid.UUID
+	Secret      string
+	ConfirmedAt sql.NullTime
+	CreatedAt   time.Time
 }
@@ -8,14 +8,30 @@ package dao
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const confirmTOTP = `-- name: ConfirmTOTP :execrows
+UPDATE user_totp
+SET confirmed_at = now()
+WHERE user_id = $1
+  AND confirmed_at IS NULL
+`
+
+func (q *Queries) ConfirmTOTP(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, confirmTOTP, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countByEmail = `-- name: CountByEmail :one
 SELECT COUNT(*)
 FROM "user"
-WHERE email = $1
+WHERE lower(email) = lower($1::TEXT)
 `
 
 func (q *Queries) CountByEmail(ctx context.Context, email string) (int64, error) {
@@ -25,11 +41,84 @@ func (q *Queries) CountByEmail(ctx context.Context, email string) (int64, error)
 	return count, err
 }
 
+const deleteAllPersonalAccessTokens = `-- name: DeleteAllPersonalAccessTokens :exec
+DELETE
+FROM personal_access_token
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteAllPersonalAccessTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAllPersonalAccessTokens, userID)
+	return err
+}
+
+const deletePersonalAccessToken = `-- name: DeletePersonalAccessToken :execrows
+DELETE
+FROM personal_access_token
+WHERE token_id = $1
+  AND user_id = $2
+`
+
+type DeletePersonalAccessTokenParams struct {
+	TokenID uuid.UUID
+	UserID  uuid.UUID
+}
+
+func (q *Queries) DeletePersonalAccessToken(ctx context.Context, arg DeletePersonalAccessTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePersonalAccessToken, arg.TokenID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRecoveryCode = `-- name: DeleteRecoveryCode :execrows
+DELETE
+FROM recovery_code
+WHERE user_id = $1
+  AND code_hash = $2
+`
+
+type DeleteRecoveryCodeParams struct {
+	UserID   uuid.UUID
+	CodeHash string
+}
+
+func (q *Queries) DeleteRecoveryCode(ctx context.Context, arg DeleteRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE
+FROM recovery_code
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, userID)
+	return err
+}
+
+const deleteTOTP = `-- name: DeleteTOTP :exec
+DELETE
+FROM user_totp
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTOTP, userID)
+	return err
+}
+
 const deleteUser = `-- name: DeleteUser :one
 DELETE
 FROM "user"
 WHERE user_id = $1
-RETURNING user_id, name, email, password, created_at, modified_at, profile_image_url
+RETURNING user_id, name, email, password, created_at, modified_at, profile_image_url, version, email_verified_at, deleted_at, roles, locked_at
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, userID uuid.UUID) (User, error) {
@@ -43,52 +132,132 @@ func (q *Queries) DeleteUser(ctx context.Context, userID uuid.UUID) (User, error
 		&i.CreatedAt,
 		&i.ModifiedAt,
 		&i.ProfileImageUrl,
+		&i.Version,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
 	)
 	return i, err
 }
 
 const findByEmail = `-- name: FindByEmail :one
-SELECT user_id, email, password
+SELECT user_id, email, password, email_verified_at, deleted_at, roles, locked_at
 FROM "user"
-WHERE email = $1
+WHERE lower(email) = lower($1::TEXT)
 `
 
 type FindByEmailRow struct {
-	UserID   uuid.UUID
-	Email    string
-	Password string
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
 }
 
 func (q *Queries) FindByEmail(ctx context.Context, email string) (FindByEmailRow, error) {
 	row := q.db.QueryRow(ctx, findByEmail, email)
 	var i FindByEmailRow
-	err := row.Scan(&i.UserID, &i.Email, &i.Password)
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
 	return i, err
 }
 
 const findById = `-- name: FindById :one
-SELECT user_id, email, password
+SELECT user_id, email, password, email_verified_at, deleted_at, roles, locked_at
 FROM "user"
 WHERE user_id = $1
 `
 
 type FindByIdRow struct {
-	UserID   uuid.UUID
-	Email    string
-	Password string
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
 }
 
 func (q *Queries) FindById(ctx context.Context, userID uuid.UUID) (FindByIdRow, error) {
 	row := q.db.QueryRow(ctx, findById, userID)
 	var i FindByIdRow
-	err := row.Scan(&i.UserID, &i.Email, &i.Password)
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const findExternalIdentity = `-- name: FindExternalIdentity :one
+SELECT provider, subject, user_id, email
+FROM external_identity
+WHERE provider = $1
+  AND subject = $2
+`
+
+type FindExternalIdentityParams struct {
+	Provider string
+	Subject  string
+}
+
+type FindExternalIdentityRow struct {
+	Provider string
+	Subject  string
+	UserID   uuid.UUID
+	Email    string
+}
+
+func (q *Queries) FindExternalIdentity(ctx context.Context, arg FindExternalIdentityParams) (FindExternalIdentityRow, error) {
+	row := q.db.QueryRow(ctx, findExternalIdentity, arg.Provider, arg.Subject)
+	var i FindExternalIdentityRow
+	err := row.Scan(
+		&i.Provider,
+		&i.Subject,
+		&i.UserID,
+		&i.Email,
+	)
+	return i, err
+}
+
+const findTOTP = `-- name: FindTOTP :one
+SELECT user_id, secret, confirmed_at
+FROM user_totp
+WHERE user_id = $1
+`
+
+type FindTOTPRow struct {
+	UserID      uuid.UUID
+	Secret      string
+	ConfirmedAt sql.NullTime
+}
+
+func (q *Queries) FindTOTP(ctx context.Context, userID uuid.UUID) (FindTOTPRow, error) {
+	row := q.db.QueryRow(ctx, findTOTP, userID)
+	var i FindTOTPRow
+	err := row.Scan(&i.UserID, &i.Secret, &i.ConfirmedAt)
 	return i, err
 }
 
 const getUserData = `-- name: GetUserData :one
-SELECT user_id, email, name, profile_image_url
+SELECT user_id, email, name, profile_image_url, version
 FROM "user"
 WHERE user_id = $1
+  AND deleted_at IS NULL
 `
 
 type GetUserDataRow struct {
@@ -96,6 +265,7 @@ type GetUserDataRow struct {
 	Email           string
 	Name            string
 	ProfileImageUrl sql.NullString
+	Version         int32
 }
 
 func (q *Queries) GetUserData(ctx context.Context, userID uuid.UUID) (GetUserDataRow, error) {
@@ -106,14 +276,38 @@ func (q *Queries) GetUserData(ctx context.Context, userID uuid.UUID) (GetUserDat
 		&i.Email,
 		&i.Name,
 		&i.ProfileImageUrl,
+		&i.Version,
 	)
 	return i, err
 }
 
+const handOverBoards = `-- name: HandOverBoards :exec
+UPDATE board b
+SET owner_id    = (SELECT o.user_id
+                   FROM board_member o
+                   WHERE o.board_id = b.board_id
+                     AND o.role = 'owner'
+                     AND o.user_id <> $1::UUID
+                   ORDER BY o.created_at
+                   LIMIT 1),
+    modified_at = now()
+WHERE b.owner_id = $1::UUID
+  AND EXISTS(SELECT 1
+             FROM board_member o
+             WHERE o.board_id = b.board_id
+               AND o.role = 'owner'
+               AND o.user_id <> $1::UUID)
+`
+
+func (q *Queries) HandOverBoards(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, handOverBoards, userID)
+	return err
+}
+
 const insertCredential = `-- name: InsertCredential :one
 INSERT INTO "user"(email, password, name)
 VALUES ($1, $2, $3)
-RETURNING user_id, email, password
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
 `
 
 type InsertCredentialParams struct {
@@ -123,15 +317,438 @@ type InsertCredentialParams struct {
 }
 
 type InsertCredentialRow struct {
-	UserID   uuid.UUID
-	Email    string
-	Password string
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
 }
 
 func (q *Queries) InsertCredential(ctx context.Context, arg InsertCredentialParams) (InsertCredentialRow, error) {
 	row := q.db.QueryRow(ctx, insertCredential, arg.Email, arg.Password, arg.Name)
 	var i InsertCredentialRow
-	err := row.Scan(&i.UserID, &i.Email, &i.Password)
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const insertExternalIdentity = `-- name: InsertExternalIdentity :one
+INSERT INTO external_identity(provider, subject, user_id, email)
+VALUES ($1, $2, $3, $4)
+RETURNING provider, subject, user_id, email
+`
+
+type InsertExternalIdentityParams struct {
+	Provider string
+	Subject  string
+	UserID   uuid.UUID
+	Email    string
+}
+
+type InsertExternalIdentityRow struct {
+	Provider string
+	Subject  string
+	UserID   uuid.UUID
+	Email    string
+}
+
+func (q *Queries) InsertExternalIdentity(ctx context.Context, arg InsertExternalIdentityParams) (InsertExternalIdentityRow, error) {
+	row := q.db.QueryRow(ctx, insertExternalIdentity, arg.Provider, arg.Subject, arg.UserID, arg.Email)
+	var i InsertExternalIdentityRow
+	err := row.Scan(
+		&i.Provider,
+		&i.Subject,
+		&i.UserID,
+		&i.Email,
+	)
+	return i, err
+}
+
+const insertPersonalAccessToken = `-- name: InsertPersonalAccessToken :one
+INSERT INTO personal_access_token(expires_at, user_id, name, token_hash, scopes)
+VALUES ($1::TIMESTAMPTZ, $2::UUID, $3::TEXT, $4::TEXT, $5::TEXT[])
+RETURNING token_id, user_id, name, scopes, created_at, expires_at, last_used_at
+`
+
+type InsertPersonalAccessTokenParams struct {
+	ExpiresAt sql.NullTime
+	UserID    uuid.UUID
+	Name      string
+	TokenHash string
+	Scopes    []string
+}
+
+type InsertPersonalAccessTokenRow struct {
+	TokenID    uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+}
+
+func (q *Queries) InsertPersonalAccessToken(ctx context.Context, arg InsertPersonalAccessTokenParams) (InsertPersonalAccessTokenRow, error) {
+	row := q.db.QueryRow(ctx, insertPersonalAccessToken, arg.ExpiresAt, arg.UserID, arg.Name, arg.TokenHash, arg.Scopes)
+	var i InsertPersonalAccessTokenRow
+	err := row.Scan(
+		&i.TokenID,
+		&i.UserID,
+		&i.Name,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
+const insertRecoveryCodes = `-- name: InsertRecoveryCodes :exec
+INSERT INTO recovery_code(user_id, code_hash)
+SELECT $1::UUID, unnest($2::TEXT[])
+`
+
+type InsertRecoveryCodesParams struct {
+	UserID     uuid.UUID
+	CodeHashes []string
+}
+
+func (q *Queries) InsertRecoveryCodes(ctx context.Context, arg InsertRecoveryCodesParams) error {
+	_, err := q.db.Exec(ctx, insertRecoveryCodes, arg.UserID, arg.CodeHashes)
+	return err
+}
+
+const listDeletedBefore = `-- name: ListDeletedBefore :many
+SELECT user_id
+FROM "user"
+WHERE deleted_at < $1::TIMESTAMPTZ
+ORDER BY deleted_at
+`
+
+func (q *Queries) ListDeletedBefore(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listDeletedBefore, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonalAccessTokens = `-- name: ListPersonalAccessTokens :many
+SELECT token_id, user_id, name, scopes, created_at, expires_at, last_used_at
+FROM personal_access_token
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+type ListPersonalAccessTokensRow struct {
+	TokenID    uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+}
+
+func (q *Queries) ListPersonalAccessTokens(ctx context.Context, userID uuid.UUID) ([]ListPersonalAccessTokensRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalAccessTokens, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalAccessTokensRow
+	for rows.Next() {
+		var i ListPersonalAccessTokensRow
+		if err := rows.Scan(
+			&i.TokenID,
+			&i.UserID,
+			&i.Name,
+			&i.Scopes,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUser = `-- name: LockUser :one
+UPDATE "user"
+SET locked_at   = coalesce(locked_at, now()),
+    modified_at = now()
+WHERE user_id = $1
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
+`
+
+type LockUserRow struct {
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+func (q *Queries) LockUser(ctx context.Context, userID uuid.UUID) (LockUserRow, error) {
+	row := q.db.QueryRow(ctx, lockUser, userID)
+	var i LockUserRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const markDeleted = `-- name: MarkDeleted :one
+UPDATE "user"
+SET deleted_at  = now(),
+    modified_at = now()
+WHERE user_id = $1
+  AND deleted_at IS NULL
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
+`
+
+type MarkDeletedRow struct {
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+func (q *Queries) MarkDeleted(ctx context.Context, userID uuid.UUID) (MarkDeletedRow, error) {
+	row := q.db.QueryRow(ctx, markDeleted, userID)
+	var i MarkDeletedRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const markEmailVerified = `-- name: MarkEmailVerified :one
+UPDATE "user"
+SET email_verified_at = now(),
+    modified_at       = now()
+WHERE user_id = $1::UUID
+  AND lower(email) = lower($2::TEXT)
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
+`
+
+type MarkEmailVerifiedParams struct {
+	UserID uuid.UUID
+	Email  string
+}
+
+type MarkEmailVerifiedRow struct {
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+func (q *Queries) MarkEmailVerified(ctx context.Context, arg MarkEmailVerifiedParams) (MarkEmailVerifiedRow, error) {
+	row := q.db.QueryRow(ctx, markEmailVerified, arg.UserID, arg.Email)
+	var i MarkEmailVerifiedRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const promoteHeirs = `-- name: PromoteHeirs :exec
+UPDATE board_member m
+SET role = 'owner'
+FROM (SELECT DISTINCT ON (heir.board_id) heir.board_id, heir.user_id
+      FROM board_member heir
+               JOIN board_member leaving ON leaving.board_id = heir.board_id
+      WHERE leaving.user_id = $1::UUID
+        AND leaving.role = 'owner'
+        AND heir.user_id <> $1::UUID
+        AND NOT EXISTS(SELECT 1
+                       FROM board_member other
+                       WHERE other.board_id = heir.board_id
+                         AND other.role = 'owner'
+                         AND other.user_id <> $1::UUID)
+      ORDER BY heir.board_id,
+               CASE heir.role WHEN 'admin' THEN 0 WHEN 'editor' THEN 1 ELSE 2 END,
+               heir.created_at) picked
+WHERE m.board_id = picked.board_id
+  AND m.user_id = picked.user_id
+`
+
+func (q *Queries) PromoteHeirs(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, promoteHeirs, userID)
+	return err
+}
+
+const restoreDeleted = `-- name: RestoreDeleted :one
+UPDATE "user"
+SET deleted_at  = NULL,
+    modified_at = now()
+WHERE user_id = $1
+  AND deleted_at IS NOT NULL
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
+`
+
+type RestoreDeletedRow struct {
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+func (q *Queries) RestoreDeleted(ctx context.Context, userID uuid.UUID) (RestoreDeletedRow, error) {
+	row := q.db.QueryRow(ctx, restoreDeleted, userID)
+	var i RestoreDeletedRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const unassignUser = `-- name: UnassignUser :exec
+UPDATE card
+SET assignee_ids = array_remove(assignee_ids, $1::UUID),
+    version      = version + 1,
+    modified_at  = now()
+WHERE $1::UUID = ANY (assignee_ids)
+`
+
+func (q *Queries) UnassignUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, unassignUser, userID)
+	return err
+}
+
+const unlockUser = `-- name: UnlockUser :one
+UPDATE "user"
+SET locked_at   = NULL,
+    modified_at = now()
+WHERE user_id = $1
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
+`
+
+type UnlockUserRow struct {
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+func (q *Queries) UnlockUser(ctx context.Context, userID uuid.UUID) (UnlockUserRow, error) {
+	row := q.db.QueryRow(ctx, unlockUser, userID)
+	var i UnlockUserRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
+	return i, err
+}
+
+const updateEmail = `-- name: UpdateEmail :one
+UPDATE "user"
+SET email             = $1::TEXT,
+    email_verified_at = now(),
+    modified_at       = now()
+WHERE user_id = $2::UUID
+  AND lower(email) = lower($3::TEXT)
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at
+`
+
+type UpdateEmailParams struct {
+	NewEmail string
+	UserID   uuid.UUID
+	OldEmail string
+}
+
+type UpdateEmailRow struct {
+	UserID          uuid.UUID
+	Email           string
+	Password        string
+	EmailVerifiedAt sql.NullTime
+	DeletedAt       sql.NullTime
+	Roles           []string
+	LockedAt        sql.NullTime
+}
+
+func (q *Queries) UpdateEmail(ctx context.Context, arg UpdateEmailParams) (UpdateEmailRow, error) {
+	row := q.db.QueryRow(ctx, updateEmail, arg.NewEmail, arg.UserID, arg.OldEmail)
+	var i UpdateEmailRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.EmailVerifiedAt,
+		&i.DeletedAt,
+		&i.Roles,
+		&i.LockedAt,
+	)
 	return i, err
 }
 
@@ -165,15 +782,18 @@ const updateUserData = `-- name: UpdateUserData :one
 UPDATE "user"
 SET name = $1,
     profile_image_url = $2,
+    version = version + 1,
     modified_at = now()
 WHERE user_id = $3
-RETURNING user_id, email, name, profile_image_url
+  AND version = $4::INTEGER
+RETURNING user_id, email, name, profile_image_url, version
 `
 
 type UpdateUserDataParams struct {
 	Name            string
 	ProfileImageUrl sql.NullString
 	UserID          uuid.UUID
+	Version         int32
 }
 
 type UpdateUserDataRow struct {
@@ -181,16 +801,73 @@ type UpdateUserDataRow struct {
 	Email           string
 	Name            string
 	ProfileImageUrl sql.NullString
+	Version         int32
 }
 
 func (q *Queries) UpdateUserData(ctx context.Context, arg UpdateUserDataParams) (UpdateUserDataRow, error) {
-	row := q.db.QueryRow(ctx, updateUserData, arg.Name, arg.ProfileImageUrl, arg.UserID)
+	row := q.db.QueryRow(ctx, updateUserData, arg.Name, arg.ProfileImageUrl, arg.UserID, arg.Version)
 	var i UpdateUserDataRow
 	err := row.Scan(
 		&i.UserID,
 		&i.Email,
 		&i.Name,
 		&i.ProfileImageUrl,
+		&i.Version,
+	)
+	return i, err
+}
+
+const upsertTOTP = `-- name: UpsertTOTP :execrows
+INSERT INTO user_totp(user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+    SET secret     = excluded.secret,
+        created_at = now()
+WHERE user_totp.confirmed_at IS NULL
+`
+
+type UpsertTOTPParams struct {
+	UserID uuid.UUID
+	Secret string
+}
+
+func (q *Queries) UpsertTOTP(ctx context.Context, arg UpsertTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertTOTP, arg.UserID, arg.Secret)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const usePersonalAccessToken = `-- name: UsePersonalAccessToken :one
+UPDATE personal_access_token
+SET last_used_at = now()
+WHERE token_hash = $1
+  AND (expires_at IS NULL OR expires_at > now())
+RETURNING token_id, user_id, name, scopes, created_at, expires_at, last_used_at
+`
+
+type UsePersonalAccessTokenRow struct {
+	TokenID    uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+}
+
+func (q *Queries) UsePersonalAccessToken(ctx context.Context, tokenHash string) (UsePersonalAccessTokenRow, error) {
+	row := q.db.QueryRow(ctx, usePersonalAccessToken, tokenHash)
+	var i UsePersonalAccessTokenRow
+	err := row.Scan(
+		&i.TokenID,
+		&i.UserID,
+		&i.Name,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
 	)
 	return i, err
 }
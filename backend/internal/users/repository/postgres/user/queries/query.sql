@@ -1,22 +1,22 @@
 -- name: FindById :one
-SELECT user_id, email, password
+SELECT user_id, email, password, email_verified_at, deleted_at, roles, locked_at
 FROM "user"
 WHERE user_id = $1;
 
 -- name: FindByEmail :one
-SELECT user_id, email, password
+SELECT user_id, email, password, email_verified_at, deleted_at, roles, locked_at
 FROM "user"
-WHERE email = $1;
+WHERE lower(email) = lower(@email::TEXT);
 
 -- name: CountByEmail :one
 SELECT COUNT(*)
 FROM "user"
-WHERE email = $1;
+WHERE lower(email) = lower(@email::TEXT);
 
 -- name: InsertCredential :one
 INSERT INTO "user"(email, password, name)
 VALUES ($1, $2, $3)
-RETURNING user_id, email, password;
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
 
 -- name: UpdatePassword :one
 UPDATE "user"
@@ -25,6 +25,103 @@ SET password    = $1,
 WHERE user_id = $2
 RETURNING user_id, email, password;
 
+-- name: MarkEmailVerified :one
+UPDATE "user"
+SET email_verified_at = now(),
+    modified_at       = now()
+WHERE user_id = @user_id::UUID
+  AND lower(email) = lower(@email::TEXT)
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
+
+-- name: UpdateEmail :one
+UPDATE "user"
+SET email             = @new_email::TEXT,
+    email_verified_at = now(),
+    modified_at       = now()
+WHERE user_id = @user_id::UUID
+  AND lower(email) = lower(@old_email::TEXT)
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
+
+-- name: MarkDeleted :one
+UPDATE "user"
+SET deleted_at  = now(),
+    modified_at = now()
+WHERE user_id = $1
+  AND deleted_at IS NULL
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
+
+-- name: RestoreDeleted :one
+UPDATE "user"
+SET deleted_at  = NULL,
+    modified_at = now()
+WHERE user_id = $1
+  AND deleted_at IS NOT NULL
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
+
+-- name: LockUser :one
+UPDATE "user"
+SET locked_at   = coalesce(locked_at, now()),
+    modified_at = now()
+WHERE user_id = $1
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
+
+-- name: UnlockUser :one
+UPDATE "user"
+SET locked_at   = NULL,
+    modified_at = now()
+WHERE user_id = $1
+RETURNING user_id, email, password, email_verified_at, deleted_at, roles, locked_at;
+
+-- name: ListDeletedBefore :many
+SELECT user_id
+FROM "user"
+WHERE deleted_at < @deleted_before::TIMESTAMPTZ
+ORDER BY deleted_at;
+
+-- name: PromoteHeirs :exec
+UPDATE board_member m
+SET role = 'owner'
+FROM (SELECT DISTINCT ON (heir.board_id) heir.board_id, heir.user_id
+      FROM board_member heir
+               JOIN board_member leaving ON leaving.board_id = heir.board_id
+      WHERE leaving.user_id = @user_id::UUID
+        AND leaving.role = 'owner'
+        AND heir.user_id <> @user_id::UUID
+        AND NOT EXISTS(SELECT 1
+                       FROM board_member other
+                       WHERE other.board_id = heir.board_id
+                         AND other.role = 'owner'
+                         AND other.user_id <> @user_id::UUID)
+      ORDER BY heir.board_id,
+               CASE heir.role WHEN 'admin' THEN 0 WHEN 'editor' THEN 1 ELSE 2 END,
+               heir.created_at) picked
+WHERE m.board_id = picked.board_id
+  AND m.user_id = picked.user_id;
+
+-- name: HandOverBoards :exec
+UPDATE board b
+SET owner_id    = (SELECT o.user_id
+                   FROM board_member o
+                   WHERE o.board_id = b.board_id
+                     AND o.role = 'owner'
+                     AND o.user_id <> @user_id::UUID
+                   ORDER BY o.created_at
+                   LIMIT 1),
+    modified_at = now()
+WHERE b.owner_id = @user_id::UUID
+  AND EXISTS(SELECT 1
+             FROM board_member o
+             WHERE o.board_id = b.board_id
+               AND o.role = 'owner'
+               AND o.user_id <> @user_id::UUID);
+
+-- name: UnassignUser :exec
+UPDATE card
+SET assignee_ids = array_remove(assignee_ids, @user_id::UUID),
+    version      = version + 1,
+    modified_at  = now()
+WHERE @user_id::UUID = ANY (assignee_ids);
+
 -- name: DeleteUser :one
 DELETE
 FROM "user"
@@ -33,13 +130,94 @@ RETURNING *;
 
 -- name: UpdateUserData :one
 UPDATE "user"
-SET name = $1,
-    profile_image_url = $2,
+SET name = @name,
+    profile_image_url = @profile_image_url,
+    version = version + 1,
     modified_at = now()
-WHERE user_id = $3
-RETURNING user_id, email, name, profile_image_url;
+WHERE user_id = @user_id
+  AND version = @version::INTEGER
+RETURNING user_id, email, name, profile_image_url, version;
 
 -- name: GetUserData :one
-SELECT user_id, email, name, profile_image_url
+SELECT user_id, email, name, profile_image_url, version
 FROM "user"
-WHERE user_id = $1;
\ No newline at end of file
+WHERE user_id = $1
+  AND deleted_at IS NULL;
+-- name: UpsertTOTP :execrows
+INSERT INTO user_totp(user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+    SET secret     = excluded.secret,
+        created_at = now()
+WHERE user_totp.confirmed_at IS NULL;
+
+-- name: FindTOTP :one
+SELECT user_id, secret, confirmed_at
+FROM user_totp
+WHERE user_id = $1;
+
+-- name: ConfirmTOTP :execrows
+UPDATE user_totp
+SET confirmed_at = now()
+WHERE user_id = $1
+  AND confirmed_at IS NULL;
+
+-- name: DeleteTOTP :exec
+DELETE
+FROM user_totp
+WHERE user_id = $1;
+
+-- name: InsertRecoveryCodes :exec
+INSERT INTO recovery_code(user_id, code_hash)
+SELECT @user_id::UUID, unnest(@code_hashes::TEXT[]);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE
+FROM recovery_code
+WHERE user_id = $1;
+
+-- name: DeleteRecoveryCode :execrows
+DELETE
+FROM recovery_code
+WHERE user_id = $1
+  AND code_hash = $2;
+
+-- name: FindExternalIdentity :one
+SELECT provider, subject, user_id, email
+FROM external_identity
+WHERE provider = $1
+  AND subject = $2;
+
+-- name: InsertExternalIdentity :one
+INSERT INTO external_identity(provider, subject, user_id, email)
+VALUES ($1, $2, $3, $4)
+RETURNING provider, subject, user_id, email;
+
+-- name: InsertPersonalAccessToken :one
+INSERT INTO personal_access_token(expires_at, user_id, name, token_hash, scopes)
+VALUES (sqlc.narg(expires_at)::TIMESTAMPTZ, @user_id::UUID, @name::TEXT, @token_hash::TEXT, @scopes::TEXT[])
+RETURNING token_id, user_id, name, scopes, created_at, expires_at, last_used_at;
+
+-- name: ListPersonalAccessTokens :many
+SELECT token_id, user_id, name, scopes, created_at, expires_at, last_used_at
+FROM personal_access_token
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeletePersonalAccessToken :execrows
+DELETE
+FROM personal_access_token
+WHERE token_id = $1
+  AND user_id = $2;
+
+-- name: UsePersonalAccessToken :one
+UPDATE personal_access_token
+SET last_used_at = now()
+WHERE token_hash = $1
+  AND (expires_at IS NULL OR expires_at > now())
+RETURNING token_id, user_id, name, scopes, created_at, expires_at, last_used_at;
+
+-- name: DeleteAllPersonalAccessTokens :exec
+DELETE
+FROM personal_access_token
+WHERE user_id = $1;
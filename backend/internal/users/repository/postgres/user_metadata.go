@@ -35,8 +35,20 @@ func (s *UserMetadataStore) Update(user *domain.User) (*domain.User, error) {
 			String: user.ImageURL,
 			Valid:  true,
 		},
-		UserID: *userUuid,
+		UserID:  *userUuid,
+		Version: user.Version,
 	})
+	if err == pgx.ErrNoRows {
+		// either the user is gone or the version is stale, only the latter is a conflict
+		_, err = s.q.GetUserData(ctx, *userUuid)
+		if err == pgx.ErrNoRows {
+			return nil, &users.Error{Code: users.ENOTFOUND, Op: op, Err: err}
+		}
+		if err != nil {
+			return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
+		}
+		return nil, &users.Error{Code: users.ECONFLICT, Op: op, Message: "user was changed by someone else, reload and try again"}
+	}
 	if err != nil {
 		return nil, &users.Error{Code: users.EINTERNAL, Op: op, Err: err}
 	}
@@ -45,6 +57,7 @@ func (s *UserMetadataStore) Update(user *domain.User) (*domain.User, error) {
 		Name:     dbUser.Name,
 		Email:    dbUser.Email,
 		ImageURL: dbUser.ProfileImageUrl.String,
+		Version:  dbUser.Version,
 	}, nil
 }
 
@@ -70,5 +83,6 @@ func (s *UserMetadataStore) Get(userID string) (*domain.User, error) {
 		Name:     dbUser.Name,
 		ImageURL: dbUser.ProfileImageUrl.String,
 		Email:    dbUser.Email,
+		Version:  dbUser.Version,
 	}, nil
 }
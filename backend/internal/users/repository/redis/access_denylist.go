@@ -0,0 +1,109 @@
+package redis
+
+import (
+	"context"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"strconv"
+	"time"
+)
+
+const (
+	// deniedTokenPrefix namespaces the IDs of rejected access tokens.
+	deniedTokenPrefix = "access-denied:token:"
+	// deniedSessionPrefix and deniedUserPrefix namespace the time before which access tokens of a
+	// session or a user were issued are rejected.
+	deniedSessionPrefix = "access-denied:session:"
+	deniedUserPrefix    = "access-denied:user:"
+)
+
+type AccessDenylist struct {
+	client *redis.Client
+	// lifetime is how long access tokens are valid.
+	lifetime time.Duration
+	// clockSkew is how long past their expiry access tokens are still accepted.
+	clockSkew time.Duration
+}
+
+func NewAccessDenylist(client *redis.Client, lifetime, clockSkew time.Duration) *AccessDenylist {
+	return &AccessDenylist{client, lifetime, clockSkew}
+}
+
+func (d *AccessDenylist) Deny(claims *domain.AccessClaims) error {
+	op := "redis.AccessDenylist.Deny"
+
+	ttl := time.Until(claims.ExpiresAt) + d.clockSkew
+	if ttl <= 0 {
+		// the token is no longer accepted anyway
+		return nil
+	}
+
+	err := d.client.Set(context.Background(), deniedTokenPrefix+claims.TokenID, "", ttl).Err()
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+func (d *AccessDenylist) DenySession(sessionID string) error {
+	op := "redis.AccessDenylist.DenySession"
+
+	err := d.denyBefore(deniedSessionPrefix + sessionID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+func (d *AccessDenylist) DenyUser(userID string) error {
+	op := "redis.AccessDenylist.DenyUser"
+
+	err := d.denyBefore(deniedUserPrefix + userID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+func (d *AccessDenylist) Denied(claims *domain.AccessClaims) (bool, error) {
+	op := "redis.AccessDenylist.Denied"
+
+	ctx := context.Background()
+
+	var tokenDenied *redis.IntCmd
+	var sessionCutoff, userCutoff *redis.StringCmd
+	_, err := d.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		tokenDenied = pipe.Exists(ctx, deniedTokenPrefix+claims.TokenID)
+		sessionCutoff = pipe.Get(ctx, deniedSessionPrefix+claims.SessionID)
+		userCutoff = pipe.Get(ctx, deniedUserPrefix+claims.UserID)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return false, &users.Error{Op: op, Err: err}
+	}
+
+	if tokenDenied.Val() != 0 {
+		return true, nil
+	}
+	for _, cutoff := range []*redis.StringCmd{sessionCutoff, userCutoff} {
+		if cutoff.Err() == redis.Nil {
+			continue
+		}
+		deniedBefore, err := strconv.ParseInt(cutoff.Val(), 10, 64)
+		if err != nil {
+			return false, &users.Error{Op: op, Err: err}
+		}
+		// issue times only have whole seconds, so tokens issued in the second of the cutoff are
+		// rejected as well
+		if claims.IssuedAt.Unix() <= deniedBefore {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// denyBefore rejects the tokens the key stands for that were issued until now.
+func (d *AccessDenylist) denyBefore(key string) error {
+	return d.client.Set(context.Background(), key, time.Now().Unix(), d.lifetime+d.clockSkew).Err()
+}
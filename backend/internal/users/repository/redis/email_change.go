@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+const (
+	// emailChangePrefix namespaces email change tokens. Like reset tokens, only a hash of the
+	// token is part of the key.
+	emailChangePrefix = "email-change:"
+	// emailChangeExpiration is how long the user has to confirm their new address.
+	emailChangeExpiration = time.Hour * 24
+)
+
+type EmailChangeStore struct {
+	client *redis.Client
+}
+
+func NewEmailChangeStore(client *redis.Client) *EmailChangeStore {
+	return &EmailChangeStore{client}
+}
+
+func (s *EmailChangeStore) Create(change *domain.EmailChange) (string, error) {
+	op := "redis.EmailChangeStore.Create"
+
+	token, err := randomToken()
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	encodedChange, err := json.Marshal(change)
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	err = s.client.Set(context.Background(), emailChangePrefix+hashToken(token), encodedChange, emailChangeExpiration).Err()
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	return token, nil
+}
+
+func (s *EmailChangeStore) Consume(token string) (*domain.EmailChange, error) {
+	op := "redis.EmailChangeStore.Consume"
+
+	changeJSON, err := s.client.GetDel(context.Background(), emailChangePrefix+hashToken(token)).Result()
+	if err == redis.Nil {
+		return nil, &users.Error{Op: op, Code: users.ENOTFOUND, Message: "confirmation link is invalid or expired", Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	var change domain.EmailChange
+	err = json.Unmarshal([]byte(changeJSON), &change)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	return &change, nil
+}
@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"strings"
+	"time"
+)
+
+const (
+	// accountFailuresPrefix and ipFailuresPrefix namespace counts of failed logins.
+	accountFailuresPrefix = "login-failures:account:"
+	ipFailuresPrefix      = "login-failures:ip:"
+	// accountLockPrefix and ipLockPrefix namespace lockouts, which expire when they are over.
+	accountLockPrefix = "login-lock:account:"
+	ipLockPrefix      = "login-lock:ip:"
+	// failureWindow is how long failed logins are remembered after the last one.
+	failureWindow = time.Hour * 24
+	// accountFreeFailures is how many logins may fail before the account is locked out. An IP
+	// address may fail more often, as many users can share one.
+	accountFreeFailures = 5
+	ipFreeFailures      = 20
+	// firstLockout is how long the first lockout lasts. Every failure after that doubles it, up
+	// to maxLockout.
+	firstLockout = time.Second * 30
+	maxLockout   = time.Hour
+)
+
+// failScript counts a failed login and, once there were too many, locks out until the lockout
+// doubled for every failure since is over.
+var failScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+redis.call("EXPIRE", KEYS[1], ARGV[4])
+local free = tonumber(ARGV[1])
+if failures < free then
+	return 0
+end
+local lockout = math.min(tonumber(ARGV[2]) * 2 ^ (failures - free), tonumber(ARGV[3]))
+redis.call("SET", KEYS[2], failures, "PX", math.floor(lockout))
+return 1
+`)
+
+type LoginLimiter struct {
+	client *redis.Client
+}
+
+func NewLoginLimiter(client *redis.Client) *LoginLimiter {
+	return &LoginLimiter{client}
+}
+
+func (l *LoginLimiter) Check(email, ip string) error {
+	op := "redis.LoginLimiter.Check"
+
+	ctx := context.Background()
+
+	var accountLock, ipLock *redis.DurationCmd
+	_, err := l.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		accountLock = pipe.PTTL(ctx, accountLockPrefix+accountKey(email))
+		ipLock = pipe.PTTL(ctx, ipLockPrefix+ip)
+		return nil
+	})
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	// PTTL is negative for keys that do not exist
+	retryAfter := accountLock.Val()
+	if ipLock.Val() > retryAfter {
+		retryAfter = ipLock.Val()
+	}
+	if retryAfter > 0 {
+		return &users.Error{Op: op, Code: users.ETOOMANY, RetryAfter: retryAfter, Message: "too many failed logins, try again later"}
+	}
+	return nil
+}
+
+func (l *LoginLimiter) Fail(email, ip string) error {
+	op := "redis.LoginLimiter.Fail"
+
+	err := l.fail(accountFailuresPrefix+accountKey(email), accountLockPrefix+accountKey(email), accountFreeFailures)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = l.fail(ipFailuresPrefix+ip, ipLockPrefix+ip, ipFreeFailures)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+func (l *LoginLimiter) Succeed(email string) error {
+	op := "redis.LoginLimiter.Succeed"
+
+	err := l.client.Del(context.Background(), accountFailuresPrefix+accountKey(email)).Err()
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+func (l *LoginLimiter) Unlock(email string) error {
+	op := "redis.LoginLimiter.Unlock"
+
+	err := l.client.Del(context.Background(), accountFailuresPrefix+accountKey(email), accountLockPrefix+accountKey(email)).Err()
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
+
+func (l *LoginLimiter) fail(failuresKey, lockKey string, freeFailures int) error {
+	return failScript.Run(
+		context.Background(),
+		l.client,
+		[]string{failuresKey, lockKey},
+		freeFailures,
+		firstLockout.Milliseconds(),
+		maxLockout.Milliseconds(),
+		int(failureWindow.Seconds()),
+	).Err()
+}
+
+// accountKey identifies the account by its email, so that logins to accounts that do not exist
+// are limited all the same.
+func accountKey(email string) string {
+	return strings.ToLower(email)
+}
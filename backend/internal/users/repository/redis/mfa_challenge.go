@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+const (
+	// challengePrefix namespaces MFA challenges. Like reset tokens, only a hash of the challenge
+	// is part of the key.
+	challengePrefix = "mfa-challenge:"
+	// challengeExpiration is how long the user has to enter their second factor.
+	challengeExpiration = time.Minute * 5
+	// challengeAttempts is how many codes may be tried per challenge, which keeps codes from
+	// being guessed. Users who run out log in again.
+	challengeAttempts = 5
+)
+
+// attemptScript counts an attempt and returns the challenge's user and attempt count. It only
+// counts challenges that still exist, as HINCRBY would otherwise create a key that never expires.
+var attemptScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return false
+end
+local attempts = redis.call("HINCRBY", KEYS[1], "attempts", 1)
+return {redis.call("HGET", KEYS[1], "user_id"), attempts}
+`)
+
+type ChallengeStore struct {
+	client *redis.Client
+}
+
+func NewChallengeStore(client *redis.Client) *ChallengeStore {
+	return &ChallengeStore{client}
+}
+
+func (s *ChallengeStore) Create(userID string) (*domain.MFAChallenge, error) {
+	op := "redis.ChallengeStore.Create"
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	ctx := context.Background()
+	key := challengePrefix + hashToken(token)
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "user_id", userID, "attempts", 0)
+		pipe.Expire(ctx, key, challengeExpiration)
+		return nil
+	})
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	challenge := domain.MFAChallenge(token)
+	return &challenge, nil
+}
+
+func (s *ChallengeStore) Attempt(challenge *domain.MFAChallenge) (string, error) {
+	op := "redis.ChallengeStore.Attempt"
+	msg := "login expired, log in again"
+
+	ctx := context.Background()
+	key := challengePrefix + hashToken(string(*challenge))
+
+	result, err := attemptScript.Run(ctx, s.client, []string{key}).Slice()
+	if err == redis.Nil {
+		return "", &users.Error{Op: op, Code: users.EINVALID, Message: msg, Err: err}
+	}
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+	userID, _ := result[0].(string)
+	attempts, _ := result[1].(int64)
+
+	if attempts > challengeAttempts {
+		err = s.client.Del(ctx, key).Err()
+		if err != nil {
+			return "", &users.Error{Op: op, Err: err}
+		}
+		return "", &users.Error{Op: op, Code: users.EINVALID, Message: "too many attempts, log in again"}
+	}
+
+	return userID, nil
+}
+
+func (s *ChallengeStore) Complete(challenge *domain.MFAChallenge) error {
+	op := "redis.ChallengeStore.Complete"
+
+	err := s.client.Del(context.Background(), challengePrefix+hashToken(string(*challenge))).Err()
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"time"
+)
+
+const (
+	oauthStatePrefix = "oauth-state:"
+	// oauthStateExpiration is how long the user has to log in at the identity provider.
+	oauthStateExpiration = time.Minute * 10
+)
+
+type OAuthStateStore struct {
+	client *redis.Client
+}
+
+func NewOAuthStateStore(client *redis.Client) *OAuthStateStore {
+	return &OAuthStateStore{client}
+}
+
+func (s *OAuthStateStore) Create(state *domain.OAuthState) (string, error) {
+	op := "redis.OAuthStateStore.Create"
+
+	key, err := randomToken()
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	err = s.client.Set(context.Background(), oauthStatePrefix+hashToken(key), encoded, oauthStateExpiration).Err()
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	return key, nil
+}
+
+func (s *OAuthStateStore) Consume(key string) (*domain.OAuthState, error) {
+	op := "redis.OAuthStateStore.Consume"
+
+	encoded, err := s.client.GetDel(context.Background(), oauthStatePrefix+hashToken(key)).Bytes()
+	if err == redis.Nil {
+		return nil, &users.Error{Op: op, Code: users.EINVALID, Message: "login expired, try again", Err: err}
+	}
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	var state domain.OAuthState
+	err = json.Unmarshal(encoded, &state)
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	return &state, nil
+}
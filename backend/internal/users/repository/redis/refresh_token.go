@@ -7,59 +7,206 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/hardiksachan/kanban_board/backend/internal/users"
 	"github.com/hardiksachan/kanban_board/backend/internal/users/core/domain"
+	"sort"
 	"time"
 )
 
+const (
+	// tokenPrefix namespaces refresh tokens, which point to their family.
+	tokenPrefix = "refresh-token:"
+	// familyPrefix namespaces token families, which hold the latest token and the session.
+	familyPrefix = "refresh-family:"
+	// userSessionsPrefix namespaces the set of token families of every user.
+	userSessionsPrefix = "user-sessions:"
+	// expiration is how long a refresh token, and a session without refreshes, stays valid.
+	expiration = time.Minute * 10
+)
+
+// Claims is what a token key holds. It only points to the user, whose credential is read again on
+// every refresh so roles and email changes show up in the next access token.
 type Claims struct {
 	ExpiresAt time.Time
 	Token     string
-	*domain.Credential
+	FamilyID  string
+	UserID    string
+}
+
+// family is what a family key holds: the only token of the family that may still be rotated,
+// and the session the family stands for.
+type family struct {
+	Token   string
+	Session *domain.Session
 }
 
 type RefreshStore struct {
 	client *redis.Client
+	// accessDenylist rejects the access tokens of families revoked for reuse, which may have
+	// been handed to whoever replayed a token.
+	accessDenylist *AccessDenylist
 }
 
-func NewRefreshTokenStore(client *redis.Client) *RefreshStore {
-	return &RefreshStore{client}
+func NewRefreshTokenStore(client *redis.Client, accessDenylist *AccessDenylist) *RefreshStore {
+	return &RefreshStore{client, accessDenylist}
 }
 
-func (s *RefreshStore) Create(credential *domain.Credential) (*domain.RefreshToken, error) {
+func (s *RefreshStore) Create(userID string, client *domain.Client) (*domain.Session, *domain.RefreshToken, error) {
 	op := "redis.RefreshStore.Create"
 
-	token, err := uuid.NewV4()
+	familyID, err := uuid.NewV4()
 	if err != nil {
-		return nil, &users.Error{Op: op, Err: err}
+		return nil, nil, &users.Error{Op: op, Err: err}
 	}
 
-	expiration := time.Minute * 10
+	claims, err := newClaims(familyID.String(), userID)
+	if err != nil {
+		return nil, nil, &users.Error{Op: op, Err: err}
+	}
 
-	claims := Claims{
-		ExpiresAt:  time.Now().Add(expiration),
-		Credential: credential,
-		Token:      token.String(),
+	now := time.Now().UTC()
+	session := &domain.Session{
+		SessionID:  claims.FamilyID,
+		UserID:     userID,
+		Client:     *client,
+		CreatedAt:  now,
+		LastUsedAt: now,
 	}
 
-	encodedClaims, err := json.Marshal(claims)
+	ctx := context.Background()
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return s.issue(ctx, pipe, claims, session)
+	})
 	if err != nil {
-		return nil, &users.Error{Op: op, Err: err}
+		return nil, nil, &users.Error{Op: op, Err: err}
+	}
+
+	return session, (*domain.RefreshToken)(&claims.Token), nil
+}
+
+func (s *RefreshStore) Rotate(token *domain.RefreshToken, client *domain.Client) (*domain.Session, *domain.RefreshToken, error) {
+	op := "redis.RefreshStore.Rotate"
+
+	ctx := context.Background()
+
+	claims, err := s.claims(ctx, op, token)
+	if err != nil {
+		return nil, nil, err
 	}
+	familyKey := familyPrefix + claims.FamilyID
+
+	var rotated *Claims
+	var session *domain.Session
+	err = s.client.Watch(ctx, func(tx *redis.Tx) error {
+		latest, err := getFamily(ctx, tx, claims.FamilyID)
+		if err == redis.Nil {
+			return &users.Error{Op: op, Code: users.ENOTFOUND, Message: "invalid refreshToken", Err: err}
+		}
+		if err != nil {
+			return err
+		}
+		if latest.Token != claims.Token {
+			return s.reused(ctx, op, claims)
+		}
+
+		rotated, err = newClaims(claims.FamilyID, claims.UserID)
+		if err != nil {
+			return err
+		}
+
+		session = latest.Session
+		session.LastUsedAt = time.Now().UTC()
+		session.Client.IP = client.IP
+		session.Client.UserAgent = client.UserAgent
+		if client.Device != "" {
+			session.Client.Device = client.Device
+		}
 
-	err = s.client.Set(context.Background(), claims.Token, encodedClaims, expiration).Err()
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return s.issue(ctx, pipe, rotated, session)
+		})
+		return err
+	}, familyKey)
+	if err == redis.TxFailedErr {
+		// another refresh with the same token won the race, so this one is a replay
+		return nil, nil, s.reused(ctx, op, claims)
+	}
 	if err != nil {
-		return nil, &users.Error{Op: op, Err: err}
+		return nil, nil, &users.Error{Op: op, Err: err}
 	}
 
-	return (*domain.RefreshToken)(&claims.Token), nil
+	return session, (*domain.RefreshToken)(&rotated.Token), nil
 }
 
-func (s *RefreshStore) Revoke(token *domain.RefreshToken) error {
+func (s *RefreshStore) Revoke(userID string, token *domain.RefreshToken) error {
 	op := "redis.RefreshStore.Revoke"
 
-	err := s.client.Del(context.Background(), string(*token)).Err()
-	if err == redis.Nil {
-		return &users.Error{Op: op, Code: users.ENOTFOUND, Message: "invalid session TokenID", Err: err}
+	ctx := context.Background()
+
+	claims, err := s.claims(ctx, op, token)
+	if err != nil {
+		return err
+	}
+	if claims.UserID != userID {
+		return &users.Error{Op: op, Code: users.ENOTFOUND, Message: "invalid refreshToken"}
+	}
+
+	err = s.revokeFamilies(ctx, claims.UserID, claims.FamilyID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
+
+// ListSessions returns the live sessions of the user, most recently used first.
+func (s *RefreshStore) ListSessions(userID string) ([]*domain.Session, error) {
+	op := "redis.RefreshStore.ListSessions"
+
+	ctx := context.Background()
+
+	familyIDs, err := s.client.SMembers(ctx, userSessionsPrefix+userID).Result()
+	if err != nil {
+		return nil, &users.Error{Op: op, Err: err}
+	}
+
+	sessions := make([]*domain.Session, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		f, err := getFamily(ctx, s.client, familyID)
+		if err == redis.Nil {
+			// the session expired, forget about it
+			err = s.client.SRem(ctx, userSessionsPrefix+userID, familyID).Err()
+			if err != nil {
+				return nil, &users.Error{Op: op, Err: err}
+			}
+			continue
+		}
+		if err != nil {
+			return nil, &users.Error{Op: op, Err: err}
+		}
+
+		sessions = append(sessions, f.Session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+func (s *RefreshStore) RevokeSession(userID, sessionID string) error {
+	op := "redis.RefreshStore.RevokeSession"
+
+	ctx := context.Background()
+
+	owned, err := s.client.SIsMember(ctx, userSessionsPrefix+userID, sessionID).Result()
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
 	}
+	if !owned {
+		return &users.Error{Op: op, Code: users.ENOTFOUND, Message: "session does not exist"}
+	}
+
+	err = s.revokeFamilies(ctx, userID, sessionID)
 	if err != nil {
 		return &users.Error{Op: op, Err: err}
 	}
@@ -67,10 +214,28 @@ func (s *RefreshStore) Revoke(token *domain.RefreshToken) error {
 	return nil
 }
 
-func (s *RefreshStore) Verify(token *domain.RefreshToken) (*domain.Credential, error) {
-	op := "redis.RefreshStore.Verify"
+func (s *RefreshStore) RevokeAllSessions(userID string) error {
+	op := "redis.RefreshStore.RevokeAllSessions"
+
+	ctx := context.Background()
+
+	familyIDs, err := s.client.SMembers(ctx, userSessionsPrefix+userID).Result()
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.revokeFamilies(ctx, userID, familyIDs...)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	return nil
+}
 
-	claimsJSON, err := s.client.Get(context.Background(), string(*token)).Result()
+// claims loads what the token was issued for. Tokens stay readable after they are rotated, which
+// is what makes replays detectable.
+func (s *RefreshStore) claims(ctx context.Context, op string, token *domain.RefreshToken) (*Claims, error) {
+	claimsJSON, err := s.client.Get(ctx, tokenPrefix+string(*token)).Result()
 	if err == redis.Nil {
 		return nil, &users.Error{Op: op, Code: users.ENOTFOUND, Message: "invalid refreshToken", Err: err}
 	}
@@ -88,5 +253,86 @@ func (s *RefreshStore) Verify(token *domain.RefreshToken) (*domain.Credential, e
 		return nil, &users.Error{Op: op, Message: "refresh token expired", Code: users.EEXPIRED}
 	}
 
-	return claims.Credential, nil
+	return &claims, nil
+}
+
+// issue stores the token and makes it the latest of its family.
+func (s *RefreshStore) issue(ctx context.Context, pipe redis.Pipeliner, claims *Claims, session *domain.Session) error {
+	encodedClaims, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	encodedFamily, err := json.Marshal(family{Token: claims.Token, Session: session})
+	if err != nil {
+		return err
+	}
+
+	pipe.Set(ctx, tokenPrefix+claims.Token, encodedClaims, expiration)
+	pipe.Set(ctx, familyPrefix+claims.FamilyID, encodedFamily, expiration)
+	pipe.SAdd(ctx, userSessionsPrefix+claims.UserID, claims.FamilyID)
+	pipe.Expire(ctx, userSessionsPrefix+claims.UserID, expiration)
+	return nil
+}
+
+// reused revokes a family one of whose rotated tokens was presented again, along with the access
+// tokens issued to the family.
+func (s *RefreshStore) reused(ctx context.Context, op string, claims *Claims) error {
+	err := s.revokeFamilies(ctx, claims.UserID, claims.FamilyID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+
+	err = s.accessDenylist.DenySession(claims.FamilyID)
+	if err != nil {
+		return &users.Error{Op: op, Err: err}
+	}
+	return &users.Error{Op: op, Code: users.EINVALID, Message: "refresh token was already used, log in again"}
+}
+
+func (s *RefreshStore) revokeFamilies(ctx context.Context, userID string, familyIDs ...string) error {
+	if len(familyIDs) == 0 {
+		return nil
+	}
+
+	familyKeys := make([]string, 0, len(familyIDs))
+	members := make([]interface{}, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		familyKeys = append(familyKeys, familyPrefix+familyID)
+		members = append(members, familyID)
+	}
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, familyKeys...)
+		pipe.SRem(ctx, userSessionsPrefix+userID, members...)
+		return nil
+	})
+	return err
+}
+
+func getFamily(ctx context.Context, client redis.Cmdable, familyID string) (*family, error) {
+	familyJSON, err := client.Get(ctx, familyPrefix+familyID).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var f family
+	err = json.Unmarshal([]byte(familyJSON), &f)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func newClaims(familyID, userID string) (*Claims, error) {
+	token, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Claims{
+		ExpiresAt: time.Now().Add(expiration),
+		Token:     token.String(),
+		FamilyID:  familyID,
+		UserID:    userID,
+	}, nil
 }
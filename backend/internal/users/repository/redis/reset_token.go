@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/go-redis/redis/v9"
+	"github.com/hardiksachan/kanban_board/backend/internal/users"
+	"time"
+)
+
+const (
+	// resetPrefix namespaces password reset tokens. Only a hash of the token is part of the key,
+	// so reading Redis is not enough to reset someone's password.
+	resetPrefix = "password-reset:"
+	// resetExpiration is how long a password reset token can be used.
+	resetExpiration = time.Minute * 30
+)
+
+type ResetStore struct {
+	client *redis.Client
+}
+
+func NewResetStore(client *redis.Client) *ResetStore {
+	return &ResetStore{client}
+}
+
+func (s *ResetStore) Create(userID string) (string, error) {
+	op := "redis.ResetStore.Create"
+
+	token, err := randomToken()
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	err = s.client.Set(context.Background(), resetPrefix+hashToken(token), userID, resetExpiration).Err()
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	return token, nil
+}
+
+func (s *ResetStore) Consume(token string) (string, error) {
+	op := "redis.ResetStore.Consume"
+
+	userID, err := s.client.GetDel(context.Background(), resetPrefix+hashToken(token)).Result()
+	if err == redis.Nil {
+		return "", &users.Error{Op: op, Code: users.ENOTFOUND, Message: "reset token is invalid or expired", Err: err}
+	}
+	if err != nil {
+		return "", &users.Error{Op: op, Err: err}
+	}
+
+	return userID, nil
+}
+
+// randomToken returns 256 random bits, encoded to be safe in URLs.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
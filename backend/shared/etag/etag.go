@@ -0,0 +1,60 @@
+package etag
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Format renders an entity version as a strong entity tag.
+func Format(version int32) string {
+	return fmt.Sprintf("%q", strconv.Itoa(int(version)))
+}
+
+// ParseIfMatch returns the version named by an If-Match header. An absent header or "*" returns
+// 0, which names no version.
+func ParseIfMatch(header string) (int32, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	// weak tags never match under If-Match, and we hand out exactly one tag per entity
+	if strings.HasPrefix(header, "W/") || strings.Contains(header, ",") {
+		return 0, fmt.Errorf("unsupported If-Match value (%s)", header)
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, fmt.Errorf("malformed entity tag (%s)", header)
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 32)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("unknown entity tag (%s)", header)
+	}
+
+	return int32(version), nil
+}
+
+// RequireIfMatch reads the version a write expects from the If-Match header of the request.
+// Writes are never unconditional, so a request without a version is answered 428 and one with a
+// malformed header 400.
+func RequireIfMatch(rw http.ResponseWriter, r *http.Request) (int32, bool) {
+	version, err := ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("invalid If-Match header. %s", err.Error()), http.StatusBadRequest)
+		return 0, false
+	}
+	if version == 0 {
+		http.Error(rw, "If-Match with the ETag of the version being changed is required", http.StatusPreconditionRequired)
+		return 0, false
+	}
+	return version, true
+}
+
+// WritePreconditionFailed answers 412 for a write whose If-Match names a stale version.
+func WritePreconditionFailed(rw http.ResponseWriter, message string) {
+	http.Error(rw, message, http.StatusPreconditionFailed)
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS board
+(
+    board_id    UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+    owner_id    UUID             NOT NULL REFERENCES "user" (user_id) ON DELETE CASCADE,
+    title       VARCHAR(100)     NOT NULL,
+    description TEXT             NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    modified_at TIMESTAMPTZ      NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS board_owner_id_idx ON board (owner_id);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS board CASCADE;
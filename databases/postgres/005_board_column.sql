@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS board_column
+(
+    column_id   UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+    board_id    UUID             NOT NULL REFERENCES board (board_id) ON DELETE CASCADE,
+    title       VARCHAR(100)     NOT NULL,
+    rank        TEXT COLLATE "C" NOT NULL,
+    created_at  TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    modified_at TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    UNIQUE (board_id, rank)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS board_column CASCADE;
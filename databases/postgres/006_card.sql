@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS card
+(
+    card_id      UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+    board_id     UUID             NOT NULL REFERENCES board (board_id) ON DELETE CASCADE,
+    column_id    UUID             NOT NULL REFERENCES board_column (column_id) ON DELETE CASCADE,
+    title        VARCHAR(200)     NOT NULL,
+    description  TEXT             NOT NULL DEFAULT '',
+    assignee_ids UUID[]           NOT NULL DEFAULT '{}',
+    due_date     TIMESTAMPTZ,
+    rank         TEXT COLLATE "C" NOT NULL,
+    version      INTEGER          NOT NULL DEFAULT 1,
+    created_at   TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    modified_at  TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    UNIQUE (column_id, rank)
+);
+
+CREATE INDEX IF NOT EXISTS card_board_id_idx ON card (board_id);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS card CASCADE;
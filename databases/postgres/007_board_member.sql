@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS board_member
+(
+    board_id   UUID        NOT NULL REFERENCES board (board_id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES "user" (user_id) ON DELETE CASCADE,
+    role       VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (board_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS board_member_user_id_idx ON board_member (user_id);
+
+INSERT INTO board_member(board_id, user_id, role)
+SELECT board_id, owner_id, 'owner'
+FROM board
+ON CONFLICT DO NOTHING;
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS board_member CASCADE;
@@ -0,0 +1,19 @@
+ALTER TABLE "user"
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE board
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE board_column
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+---- create above / drop below ----
+
+ALTER TABLE board_column
+    DROP COLUMN version;
+
+ALTER TABLE board
+    DROP COLUMN version;
+
+ALTER TABLE "user"
+    DROP COLUMN version;
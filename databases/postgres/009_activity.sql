@@ -0,0 +1,33 @@
+-- activity is append-only: rows are never updated or deleted, and they outlive the boards and
+-- users they mention, so there are no foreign keys.
+CREATE TABLE IF NOT EXISTS activity
+(
+    activity_id BIGSERIAL PRIMARY KEY,
+    actor_id    UUID        NOT NULL,
+    board_id    UUID        NULL,
+    type        VARCHAR(50) NOT NULL,
+    subject_id  UUID        NOT NULL,
+    details     JSONB       NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS activity_board_id_idx ON activity (board_id, activity_id DESC) WHERE board_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS activity_actor_id_idx ON activity (actor_id, activity_id DESC);
+
+CREATE OR REPLACE FUNCTION activity_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'activity is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activity_append_only
+    BEFORE UPDATE OR DELETE
+    ON activity
+    FOR EACH ROW
+EXECUTE FUNCTION activity_append_only();
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS activity CASCADE;
+DROP FUNCTION IF EXISTS activity_append_only();
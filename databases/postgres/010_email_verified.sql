@@ -0,0 +1,7 @@
+ALTER TABLE "user"
+    ADD COLUMN email_verified_at TIMESTAMPTZ NULL;
+
+---- create above / drop below ----
+
+ALTER TABLE "user"
+    DROP COLUMN email_verified_at;
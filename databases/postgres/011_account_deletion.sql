@@ -0,0 +1,13 @@
+-- deleted accounts are kept for a grace period, during which they can be restored, and purged
+-- afterwards
+ALTER TABLE "user"
+    ADD COLUMN deleted_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS user_deleted_at_idx ON "user" (deleted_at) WHERE deleted_at IS NOT NULL;
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS user_deleted_at_idx;
+
+ALTER TABLE "user"
+    DROP COLUMN deleted_at;
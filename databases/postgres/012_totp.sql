@@ -0,0 +1,22 @@
+-- a TOTP secret only protects logins once the user confirmed it with a code from their app
+CREATE TABLE IF NOT EXISTS user_totp
+(
+    user_id      UUID PRIMARY KEY NOT NULL REFERENCES "user" (user_id) ON DELETE CASCADE,
+    secret       TEXT             NOT NULL,
+    confirmed_at TIMESTAMPTZ      NULL,
+    created_at   TIMESTAMPTZ      NOT NULL DEFAULT now()
+);
+
+-- recovery codes are only stored hashed, and deleted once used
+CREATE TABLE IF NOT EXISTS recovery_code
+(
+    user_id    UUID        NOT NULL REFERENCES "user" (user_id) ON DELETE CASCADE,
+    code_hash  TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, code_hash)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS recovery_code CASCADE;
+DROP TABLE IF EXISTS user_totp CASCADE;
@@ -0,0 +1,15 @@
+-- identities at external identity providers that users log in with, one per provider and user
+CREATE TABLE IF NOT EXISTS external_identity
+(
+    provider   VARCHAR(50) NOT NULL,
+    subject    TEXT        NOT NULL,
+    user_id    UUID        NOT NULL REFERENCES "user" (user_id) ON DELETE CASCADE,
+    email      TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, subject),
+    UNIQUE (user_id, provider)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS external_identity CASCADE;
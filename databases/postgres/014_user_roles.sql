@@ -0,0 +1,8 @@
+-- roles are granted to users across the whole app, e.g. to operators, and end up in their tokens
+ALTER TABLE "user"
+    ADD COLUMN roles TEXT[] NOT NULL DEFAULT '{}';
+
+---- create above / drop below ----
+
+ALTER TABLE "user"
+    DROP COLUMN roles;
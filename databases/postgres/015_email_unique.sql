@@ -0,0 +1,31 @@
+-- emails identify users regardless of case, and are stored the way domain.NormalizeEmail writes
+-- them. Users whose emails only differ in case or surrounding spaces have to be merged before
+-- this can be applied, so it stops and names them instead of picking one.
+DO
+$$
+    DECLARE
+        duplicates TEXT;
+    BEGIN
+        SELECT string_agg(normalized, ', ')
+        INTO duplicates
+        FROM (SELECT lower(trim(email)) AS normalized
+              FROM "user"
+              GROUP BY lower(trim(email))
+              HAVING count(*) > 1) d;
+
+        IF duplicates IS NOT NULL THEN
+            RAISE EXCEPTION 'users share these emails once normalized, merge them first: %', duplicates;
+        END IF;
+    END
+$$;
+
+UPDATE "user"
+SET email = lower(trim(email))
+WHERE email <> lower(trim(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_email_lower_key ON "user" (lower(email));
+
+---- create above / drop below ----
+
+-- emails stay normalized, the original spelling is gone
+DROP INDEX IF EXISTS user_email_lower_key;
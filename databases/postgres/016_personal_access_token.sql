@@ -0,0 +1,18 @@
+-- long-lived tokens for scripts, only stored as a hash of the token
+CREATE TABLE IF NOT EXISTS personal_access_token
+(
+    token_id     UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+    user_id      UUID             NOT NULL REFERENCES "user" (user_id) ON DELETE CASCADE,
+    name         VARCHAR(100)     NOT NULL,
+    token_hash   TEXT             NOT NULL UNIQUE,
+    scopes       TEXT[]           NOT NULL,
+    created_at   TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ      NULL,
+    last_used_at TIMESTAMPTZ      NULL
+);
+
+CREATE INDEX IF NOT EXISTS personal_access_token_user_id_idx ON personal_access_token (user_id);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS personal_access_token CASCADE;
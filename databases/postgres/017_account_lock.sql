@@ -0,0 +1,9 @@
+-- admins can lock accounts, e.g. ones that were taken over, which keeps them from logging in
+-- until an admin unlocks them again
+ALTER TABLE "user"
+    ADD COLUMN locked_at TIMESTAMPTZ NULL;
+
+---- create above / drop below ----
+
+ALTER TABLE "user"
+    DROP COLUMN locked_at;